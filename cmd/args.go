@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/lipgloss/tree"
 	"github.com/samwightt/gqlx/pkg/render"
 	"github.com/spf13/cobra"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -23,6 +25,7 @@ type argsOptions struct {
 	name           string
 	nameRegex      string
 	hasDescription bool
+	expand         bool
 }
 
 func isArgDeprecated(arg *ast.ArgumentDefinition) bool {
@@ -64,7 +67,7 @@ func formatArgText(arg ArgInfo) string {
 	if arg.Description != "" {
 		desc = " # " + strings.ReplaceAll(arg.Description, "\n", " ")
 	}
-	return fmt.Sprintf("%s: %s%s", name, typeStr, desc)
+	return fmt.Sprintf("%s%s: %s%s", locationPrefix(arg.Location), name, typeStr, desc)
 }
 
 func formatArgsPretty(args []ArgInfo) string {
@@ -77,13 +80,127 @@ func formatArgsPretty(args []ArgInfo) string {
 			typeStr += " = " + arg.DefaultValue
 		}
 		desc := strings.ReplaceAll(arg.Description, "\n", " ")
-		t.Row(name, typeStr, desc)
+		t.Row(name, typeStr, desc, formatLocation(arg.Location))
 	}
-	t.Headers("argument", "type", "description")
+	t.Headers("argument", "type", "description", "location")
 
 	return t.String()
 }
 
+// Input Expansion
+//
+// With --expand, arguments of input object types are expanded into a tree of
+// their fields, and the fields of nested input types in turn. An input type
+// that is already being expanded further up the tree (such as a filter with
+// and/or lists of itself) is marked recursive instead of expanded again.
+
+// expandInputType returns the enum values of an enum type, or the expanded
+// fields of an input type. ancestors are the input types being expanded above.
+func expandInputType(schema *ast.Schema, t *ast.Type, ancestors []string) (enumValues []string, recursive bool, fields []InputFieldInfo) {
+	def := schema.Types[t.Name()]
+	if def == nil {
+		return nil, false, nil
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		return slices.Collect(pluck(def.EnumValues, func(v *ast.EnumValueDefinition) string { return v.Name })), false, nil
+	case ast.InputObject:
+		if slices.Contains(ancestors, def.Name) {
+			return nil, true, nil
+		}
+		ancestors = append(slices.Clone(ancestors), def.Name)
+		for _, field := range def.Fields {
+			info := InputFieldInfo{
+				Name:        field.Name,
+				Type:        typeToString(field.Type),
+				Description: field.Description,
+				Required:    field.Type.NonNull && field.DefaultValue == nil,
+			}
+			if field.DefaultValue != nil {
+				info.DefaultValue = field.DefaultValue.String()
+			}
+			info.EnumValues, info.Recursive, info.Fields = expandInputType(schema, field.Type, ancestors)
+			fields = append(fields, info)
+		}
+	}
+	return nil, false, fields
+}
+
+// expandArg adds the expanded input tree to an argument.
+func expandArg(schema *ast.Schema, arg *ast.ArgumentDefinition, info *ArgInfo) {
+	info.Required = arg.Type.NonNull && arg.DefaultValue == nil
+	info.EnumValues, info.Recursive, info.Fields = expandInputType(schema, arg.Type, nil)
+}
+
+// argInputField returns an expanded argument as the root of its input tree.
+func argInputField(arg ArgInfo) InputFieldInfo {
+	return InputFieldInfo{
+		Name:         formatArgName(arg),
+		Type:         arg.Type,
+		DefaultValue: arg.DefaultValue,
+		Description:  arg.Description,
+		Required:     arg.Required,
+		EnumValues:   arg.EnumValues,
+		Recursive:    arg.Recursive,
+		Fields:       arg.Fields,
+	}
+}
+
+// formatInputField formats one line of an input tree, e.g.
+// "status: OrderStatus = PENDING (PENDING | PAID)".
+func formatInputField(f InputFieldInfo) string {
+	line := f.Name + ": " + f.Type
+	if f.DefaultValue != "" {
+		line += " = " + f.DefaultValue
+	}
+	if f.Required {
+		line += " (required)"
+	}
+	if len(f.EnumValues) > 0 {
+		line += " [" + strings.Join(f.EnumValues, " | ") + "]"
+	}
+	if f.Recursive {
+		line += " (recursive)"
+	}
+	if f.Description != "" {
+		line += " # " + strings.ReplaceAll(f.Description, "\n", " ")
+	}
+	return line
+}
+
+func formatInputTreeText(f InputFieldInfo, indent string) string {
+	lines := []string{indent + formatInputField(f)}
+	for _, child := range f.Fields {
+		lines = append(lines, formatInputTreeText(child, indent+"  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatInputTreePretty(f InputFieldInfo) *tree.Tree {
+	t := tree.Root(formatInputField(f))
+	for _, child := range f.Fields {
+		if len(child.Fields) > 0 {
+			t.Child(formatInputTreePretty(child))
+		} else {
+			t.Child(formatInputField(child))
+		}
+	}
+	return t
+}
+
+func formatExpandedArgText(arg ArgInfo) string {
+	return locationPrefix(arg.Location) + formatInputTreeText(argInputField(arg), "")
+}
+
+func formatExpandedArgsPretty(args []ArgInfo) string {
+	trees := make([]string, len(args))
+	for i, arg := range args {
+		trees[i] = locationPrefix(arg.Location) + formatInputTreePretty(argInputField(arg)).String()
+	}
+	return strings.Join(trees, "\n\n")
+}
+
 func argToInfo(arg *ast.ArgumentDefinition) ArgInfo {
 	var defaultValue string
 	if arg.DefaultValue != nil {
@@ -95,6 +212,7 @@ func argToInfo(arg *ast.ArgumentDefinition) ArgInfo {
 		Type:         typeToString(arg.Type),
 		DefaultValue: defaultValue,
 		Description:  arg.Description,
+		Location:     sourceLocation(arg.Position),
 	}
 }
 
@@ -129,7 +247,24 @@ func NewArgsCmd() *cobra.Command {
 		Long: `Lists arguments on fields in the schema.
 
 If a field is specified (as Type.field), only arguments for that field are shown.
-If no field is specified, all arguments for all fields are shown.`,
+If no field is specified, all arguments for all fields are shown.
+
+With --expand, arguments of input object types are expanded into a tree of
+their fields, recursively through nested input types. Each field shows its
+type, default value, whether it's required (non-null without a default), the
+values of enum types and its description. Input types that contain themselves
+are marked (recursive) where they repeat instead of being expanded again.
+
+Output formats with --expand:
+  text    One line per argument or field, indented by nesting
+  json    Arguments with nested "fields" (and "required", "enumValues",
+          "recursive")
+  pretty  A tree per argument (default in terminal)`,
+		Example: `  # List the arguments of a mutation
+  gqlx args Mutation.createOrder
+
+  # Expand input objects into a tree of all their fields
+  gqlx args Mutation.createOrder --expand`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runArgs(cmd, args, opts)
 		},
@@ -142,6 +277,7 @@ If no field is specified, all arguments for all fields are shown.`,
 	cmd.Flags().StringVar(&opts.name, "name", "", "Filter arguments by name using a glob pattern (e.g., *Id, first*)")
 	cmd.Flags().StringVar(&opts.nameRegex, "name-regex", "", "Filter arguments by name using a regex pattern")
 	cmd.Flags().BoolVar(&opts.hasDescription, "has-description", false, "Filter to only show arguments that have a description")
+	cmd.Flags().BoolVar(&opts.expand, "expand", false, "Expand input object arguments into a tree of their fields")
 
 	return cmd
 }
@@ -190,6 +326,9 @@ func runArgs(cmd *cobra.Command, args []string, opts *argsOptions) error {
 					info := argToInfo(arg)
 					info.TypeName = graphqlType.Name
 					info.FieldName = field.Name
+					if opts.expand {
+						expandArg(schema, arg, &info)
+					}
 					argInfos = append(argInfos, info)
 				}
 			}
@@ -239,7 +378,11 @@ func runArgs(cmd *cobra.Command, args []string, opts *argsOptions) error {
 			if nameRegex != nil && !nameRegex.MatchString(arg.Name) {
 				continue
 			}
-			argInfos = append(argInfos, argToInfo(arg))
+			info := argToInfo(arg)
+			if opts.expand {
+				expandArg(schema, arg, &info)
+			}
+			argInfos = append(argInfos, info)
 		}
 	}
 
@@ -252,6 +395,10 @@ func runArgs(cmd *cobra.Command, args []string, opts *argsOptions) error {
 		TextFormat:   formatArgText,
 		PrettyFormat: formatArgsPretty,
 	}
+	if opts.expand {
+		renderer.TextFormat = formatExpandedArgText
+		renderer.PrettyFormat = formatExpandedArgsPretty
+	}
 
 	output, err := renderer.Render(outputFormat)
 	if err != nil {
@@ -618,3 +618,109 @@ func TestArgs_HasDescriptionFilter_NoMatches(t *testing.T) {
 
 	assert.Len(t, args, 0)
 }
+
+const expandSchema = `
+	type Query {
+		version: String
+	}
+
+	type Mutation {
+		createOrder(input: CreateOrderInput!, dryRun: Boolean = false): ID
+	}
+
+	input CreateOrderInput {
+		"Who places the order"
+		customerId: ID!
+		items: [OrderItemInput!]!
+		status: OrderStatus = PENDING
+		filter: OrderFilter
+	}
+
+	input OrderItemInput {
+		productId: ID!
+		quantity: Int = 1
+	}
+
+	input OrderFilter {
+		and: [OrderFilter!]
+		status: OrderStatus
+	}
+
+	enum OrderStatus {
+		PENDING
+		PAID
+	}
+`
+
+func TestArgs_Expand_Text(t *testing.T) {
+	schemaPath := writeTestSchema(t, expandSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"args", "-s", schemaPath, "-f", "text", "--expand", "Mutation.createOrder"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `schema.graphql:7: input: CreateOrderInput! (required)
+  customerId: ID! (required) # Who places the order
+  items: [OrderItemInput!]! (required)
+    productId: ID! (required)
+    quantity: Int = 1
+  status: OrderStatus = PENDING [PENDING | PAID]
+  filter: OrderFilter
+    and: [OrderFilter!] (recursive)
+    status: OrderStatus [PENDING | PAID]
+schema.graphql:7: dryRun: Boolean = false
+`, stdout)
+}
+
+func TestArgs_Expand_Pretty(t *testing.T) {
+	schemaPath := writeTestSchema(t, expandSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"args", "-s", schemaPath, "-f", "pretty", "--expand", "Mutation.createOrder"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "input: CreateOrderInput! (required)\n├── customerId: ID! (required)")
+	assert.Contains(t, stdout, "│   └── quantity: Int = 1")
+	assert.Contains(t, stdout, "└── filter: OrderFilter\n    ├── and: [OrderFilter!] (recursive)")
+}
+
+func TestArgs_Expand_JSON(t *testing.T) {
+	schemaPath := writeTestSchema(t, expandSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"args", "-s", schemaPath, "-f", "json", "--expand", "Mutation.createOrder"})
+	require.NoError(t, err)
+
+	type inputField struct {
+		Name         string       `json:"name"`
+		Type         string       `json:"type"`
+		DefaultValue string       `json:"defaultValue"`
+		Required     bool         `json:"required"`
+		EnumValues   []string     `json:"enumValues"`
+		Recursive    bool         `json:"recursive"`
+		Fields       []inputField `json:"fields"`
+	}
+
+	var args []inputField
+	require.NoError(t, json.Unmarshal([]byte(stdout), &args))
+	require.Len(t, args, 2)
+
+	input := args[0]
+	assert.True(t, input.Required)
+	require.Len(t, input.Fields, 4)
+	assert.Equal(t, inputField{Name: "items", Type: "[OrderItemInput!]!", Required: true, Fields: []inputField{
+		{Name: "productId", Type: "ID!", Required: true},
+		{Name: "quantity", Type: "Int", DefaultValue: "1"},
+	}}, input.Fields[1])
+	assert.Equal(t, []string{"PENDING", "PAID"}, input.Fields[2].EnumValues)
+	assert.True(t, input.Fields[3].Fields[0].Recursive)
+	assert.Empty(t, input.Fields[3].Fields[0].Fields)
+
+	assert.Equal(t, inputField{Name: "dryRun", Type: "Boolean", DefaultValue: "false"}, args[1])
+}
+
+func TestArgs_WithoutExpand_NoInputTree(t *testing.T) {
+	schemaPath := writeTestSchema(t, expandSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"args", "-s", schemaPath, "-f", "json", "Mutation.createOrder"})
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "fields")
+	assert.NotContains(t, stdout, "required")
+}
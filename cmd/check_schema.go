@@ -0,0 +1,751 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/diagnostic"
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+func NewCheckSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-schema",
+		Short: "Reports every error in the schema",
+		Long: `Parses and validates the schema and reports every error in it, instead of
+only the first one that stops other commands from loading it.
+
+Files with syntax errors are reported first: the rest of the schema is only
+validated once every file parses. With --subgraph, each subgraph file is
+checked on its own, as a federation subgraph.
+
+Exit codes:
+  0 - Schema is valid
+  1 - Schema has errors
+
+Output formats:
+  text    Errors with source snippets and suggestions
+  json    {"valid": bool, "errors": [{"message", "location", "rule", "suggestion"}, ...]}`,
+		Example: `  # Check the schema
+  gqlx check-schema -s schema.graphql
+
+  # JSON output for CI
+  gqlx check-schema -f json
+
+  # Check subgraph schemas before composing them
+  gqlx check-schema --subgraph accounts.graphql --subgraph products.graphql`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         runCheckSchema,
+	}
+
+	return cmd
+}
+
+// maxSchemaErrors is how many validation errors are collected before giving
+// up, in case recovering from one keeps causing another.
+const maxSchemaErrors = 100
+
+// schemaErrors is every error found in a schema's sources.
+type schemaErrors struct {
+	errors  []SchemaError
+	sources []*ast.Source
+}
+
+func (e *schemaErrors) Error() string {
+	return fmt.Sprintf("GraphQL schema parsing error (%s):\n%s", errorCount(len(e.errors)), strings.TrimSuffix(formatSchemaErrors(e.errors, e.sources), "\n"))
+}
+
+// Schema Error Recovery
+//
+// gqlparser stops at the first error in a schema, and its schema errors have
+// no rule name. To report every error, the schema is validated again after
+// each one, with the element the error is about removed or replaced, the way
+// a compiler recovers from an error to keep going.
+//
+// Errors are classified by their message into rules named after the GraphQL
+// spec's validation rules. Each rule knows how to recover; errors that can't
+// be recovered from end the check, with the errors found so far.
+
+// schemaRule is a kind of schema error, matched by its message.
+type schemaRule struct {
+	name    string
+	message *regexp.Regexp
+	// recover removes or replaces what the error at pos is about, given the
+	// message's submatches. It reports whether it could.
+	recover func(c *schemaChecker, pos SourceLocation, match []string) bool
+	// suggest returns the names the error's unknown name could have meant
+	suggest func(c *schemaChecker, match []string) iter.Seq[string]
+}
+
+var schemaRules = []schemaRule{
+	{
+		name:    "UniqueTypeNames",
+		message: regexp.MustCompile(`^Cannot redeclare type (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return deleteAt(&c.doc.Definitions, pos, func(d *ast.Definition) *ast.Position { return d.Position })
+		},
+	},
+	{
+		name:    "PossibleTypeExtensions",
+		message: regexp.MustCompile(`^Cannot extend type (\w+) because`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return deleteAt(&c.doc.Extensions, pos, func(d *ast.Definition) *ast.Position { return d.Position })
+		},
+	},
+	{
+		name:    "UniqueDirectiveNames",
+		message: regexp.MustCompile(`^Cannot redeclare directive (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return deleteAt(&c.doc.Directives, pos, func(d *ast.DirectiveDefinition) *ast.Position { return d.Position })
+		},
+	},
+	{
+		name:    "LoneSchemaDefinition",
+		message: regexp.MustCompile(`^Cannot have multiple schema entry points`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return deleteAt(&c.doc.Schema, pos, func(d *ast.SchemaDefinition) *ast.Position { return d.Position })
+		},
+	},
+	{
+		name:    "KnownTypeNames",
+		message: regexp.MustCompile(`^Schema root \w+ refers to a type (\w+) that does not exist\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			for _, def := range append(slices.Clone(c.doc.Schema), c.doc.SchemaExtension...) {
+				if deleteAt(&def.OperationTypes, pos, func(o *ast.OperationTypeDefinition) *ast.Position { return o.Position }) {
+					return true
+				}
+			}
+			return false
+		},
+		suggest: func(c *schemaChecker, match []string) iter.Seq[string] {
+			return slices.Values(c.typeNames(ast.Object))
+		},
+	},
+	{
+		name:    "KnownTypeNames",
+		message: regexp.MustCompile(`^Undefined type "?(\w+)"?\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			// A field or argument type is replaced by String, so that every
+			// other use of the type is still reported
+			if typ := c.typeAt(pos); typ != nil {
+				for typ.Elem != nil {
+					typ = typ.Elem
+				}
+				typ.NamedType = "String"
+				return true
+			}
+			// Otherwise, it's a union member or an implemented interface
+			return c.removeFromType(pos, match[1])
+		},
+		suggest: func(c *schemaChecker, match []string) iter.Seq[string] {
+			return slices.Values(c.typeNames())
+		},
+	},
+	{
+		name:    "UnionMemberTypes",
+		message: regexp.MustCompile(`^UNION type "(\w+)" must be OBJECT\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeFromType(pos, match[1])
+		},
+	},
+	{
+		name:    "InterfaceImplementation",
+		message: regexp.MustCompile(`^"(\w+)" is a non interface type \w+\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeFromType(pos, match[1])
+		},
+		suggest: func(c *schemaChecker, match []string) iter.Seq[string] {
+			return slices.Values(c.typeNames(ast.Interface))
+		},
+	},
+	{
+		name:    "InterfaceImplementation",
+		message: regexp.MustCompile(`^For (\w+) to implement (\w+) `),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeInterface(match[1], match[2])
+		},
+	},
+	{
+		name:    "InterfaceImplementation",
+		message: regexp.MustCompile(`^Type (\w+) must implement \w+ because it is implemented by (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeInterface(match[1], match[2])
+		},
+	},
+	{
+		name:    "InterfaceImplementation",
+		message: regexp.MustCompile(`^Type (\w+) cannot implement (\w+) because it would create a circular reference\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeInterface(match[1], match[2])
+		},
+	},
+	{
+		name:    "FieldsDefined",
+		message: regexp.MustCompile(`^\w+ \w+: must define one or more (input )?fields\.$`),
+	},
+	{
+		name:    "EnumValuesDefined",
+		message: regexp.MustCompile(`^ENUM \w+: must define one or more unique enum values\.$`),
+	},
+	{
+		name:    "EnumValueNames",
+		message: regexp.MustCompile(`^ENUM (\w+): non-enum value (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			removed := false
+			for _, def := range c.definitions(match[1]) {
+				if i := slices.IndexFunc(def.EnumValues, func(v *ast.EnumValueDefinition) bool { return v.Name == match[2] }); i >= 0 {
+					def.EnumValues = slices.Delete(def.EnumValues, i, i+1)
+					removed = true
+				}
+			}
+			return removed
+		},
+	},
+	{
+		name:    "OutputFieldTypes",
+		message: regexp.MustCompile(`: field must be one of SCALAR, OBJECT, INTERFACE, UNION, ENUM\.$`),
+		recover: replaceFieldType,
+	},
+	{
+		name:    "InputFieldTypes",
+		message: regexp.MustCompile(`: field must be one of SCALAR, ENUM, INPUT_OBJECT\.$`),
+		recover: replaceFieldType,
+	},
+	{
+		name:    "UniqueFieldNames",
+		message: regexp.MustCompile(`^Field (\w+)\.(\w+) can only be defined once\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			for _, def := range c.definitions(match[1]) {
+				if deleteAt(&def.Fields, pos, func(f *ast.FieldDefinition) *ast.Position { return f.Position }) {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	{
+		name:    "ReservedNames",
+		message: regexp.MustCompile(`^Name "(\w+)" must not begin with "__"`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			if owner := c.fieldOwnerAt(pos); owner != nil {
+				return deleteAt(&owner.Fields, pos, func(f *ast.FieldDefinition) *ast.Position { return f.Position })
+			}
+			if args := c.argumentsAt(pos); args != nil {
+				return deleteAt(args, pos, func(a *ast.ArgumentDefinition) *ast.Position { return a.Position })
+			}
+			return c.removeDirectiveAt(pos)
+		},
+	},
+	{
+		name:    "ArgumentInputTypes",
+		message: regexp.MustCompile(`^cannot use \S+ as argument (\w+) because \w+ is not a valid input type$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			args := c.argumentsAt(pos)
+			if args == nil {
+				return false
+			}
+			arg := (*args)[slices.IndexFunc(*args, func(a *ast.ArgumentDefinition) bool { return samePosition(a.Position, pos) })]
+			arg.Type = ast.NamedType("String", arg.Type.Position)
+			return true
+		},
+	},
+	{
+		name:    "KnownDirectives",
+		message: regexp.MustCompile(`^Undefined directive (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeDirectiveAt(pos)
+		},
+		suggest: func(c *schemaChecker, match []string) iter.Seq[string] {
+			return pluck(c.doc.Directives, func(d *ast.DirectiveDefinition) string { return d.Name })
+		},
+	},
+	{
+		name:    "KnownDirectives",
+		message: regexp.MustCompile(`^Directive (\w+) is not applicable on \w+\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeDirectiveAt(pos)
+		},
+	},
+	{
+		name:    "NoDirectiveSelfReference",
+		message: regexp.MustCompile(`^Directive (\w+) cannot refer to itself\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeDirectiveAt(pos)
+		},
+	},
+	{
+		name:    "KnownArgumentNames",
+		message: regexp.MustCompile(`^Undefined argument (\w+) for directive (\w+)\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			for _, list := range c.directiveLists() {
+				for _, dir := range *list {
+					if deleteAt(&dir.Arguments, pos, func(a *ast.Argument) *ast.Position { return a.Position }) {
+						return true
+					}
+				}
+			}
+			return false
+		},
+		suggest: func(c *schemaChecker, match []string) iter.Seq[string] {
+			for _, dir := range c.doc.Directives {
+				if dir.Name == match[2] {
+					return pluck(dir.Arguments, func(a *ast.ArgumentDefinition) string { return a.Name })
+				}
+			}
+			return nil
+		},
+	},
+	{
+		name:    "ProvidedRequiredArguments",
+		message: regexp.MustCompile(`^Argument (\w+) for directive (\w+) cannot be null\.$`),
+		recover: func(c *schemaChecker, pos SourceLocation, match []string) bool {
+			return c.removeDirectiveAt(pos)
+		},
+	},
+}
+
+// schemaChecker collects the errors in a schema document, recovering from
+// each one by changing the document.
+type schemaChecker struct {
+	doc *ast.SchemaDocument
+}
+
+func samePosition(p *ast.Position, loc SourceLocation) bool {
+	return p != nil && p.Src != nil && p.Src.Name == loc.File && p.Line == loc.Line && p.Column == loc.Column
+}
+
+// deleteAt deletes the element of a list at the given position, and reports
+// whether there was one.
+func deleteAt[S ~[]E, E any](list *S, pos SourceLocation, position func(E) *ast.Position) bool {
+	i := slices.IndexFunc(*list, func(e E) bool { return samePosition(position(e), pos) })
+	if i < 0 {
+		return false
+	}
+	*list = slices.Delete(*list, i, i+1)
+	return true
+}
+
+// definitions returns the definition and extensions of a type.
+func (c *schemaChecker) definitions(name string) []*ast.Definition {
+	return filterSlice(append(slices.Clone(c.doc.Definitions), c.doc.Extensions...), func(d *ast.Definition) bool {
+		return d.Name == name
+	})
+}
+
+// typeNames returns the names of the types, of the given kinds if any.
+func (c *schemaChecker) typeNames(kinds ...ast.DefinitionKind) []string {
+	var names []string
+	for _, def := range c.doc.Definitions {
+		if !strings.HasPrefix(def.Name, "__") && (len(kinds) == 0 || slices.Contains(kinds, def.Kind)) {
+			names = append(names, def.Name)
+		}
+	}
+	return names
+}
+
+// fieldOwnerAt returns the definition or extension with a field at pos.
+func (c *schemaChecker) fieldOwnerAt(pos SourceLocation) *ast.Definition {
+	for _, def := range append(slices.Clone(c.doc.Definitions), c.doc.Extensions...) {
+		for _, field := range def.Fields {
+			if samePosition(field.Position, pos) {
+				return def
+			}
+		}
+	}
+	return nil
+}
+
+// argumentLists returns the argument definitions of every field and directive.
+func (c *schemaChecker) argumentLists() []*ast.ArgumentDefinitionList {
+	var lists []*ast.ArgumentDefinitionList
+	for _, def := range append(slices.Clone(c.doc.Definitions), c.doc.Extensions...) {
+		for _, field := range def.Fields {
+			lists = append(lists, &field.Arguments)
+		}
+	}
+	for _, dir := range c.doc.Directives {
+		lists = append(lists, &dir.Arguments)
+	}
+	return lists
+}
+
+// argumentsAt returns the argument definitions with an argument at pos.
+func (c *schemaChecker) argumentsAt(pos SourceLocation) *ast.ArgumentDefinitionList {
+	for _, args := range c.argumentLists() {
+		for _, arg := range *args {
+			if samePosition(arg.Position, pos) {
+				return args
+			}
+		}
+	}
+	return nil
+}
+
+// typeAt returns the field or argument type at pos.
+func (c *schemaChecker) typeAt(pos SourceLocation) *ast.Type {
+	for _, def := range append(slices.Clone(c.doc.Definitions), c.doc.Extensions...) {
+		for _, field := range def.Fields {
+			if samePosition(field.Type.Position, pos) {
+				return field.Type
+			}
+		}
+	}
+	for _, args := range c.argumentLists() {
+		for _, arg := range *args {
+			if samePosition(arg.Type.Position, pos) {
+				return arg.Type
+			}
+		}
+	}
+	return nil
+}
+
+// removeFromType removes a union member or interface from the type defined at
+// pos, along with its extensions.
+func (c *schemaChecker) removeFromType(pos SourceLocation, name string) bool {
+	for _, def := range append(slices.Clone(c.doc.Definitions), c.doc.Extensions...) {
+		if !samePosition(def.Position, pos) {
+			continue
+		}
+		removed := false
+		for _, d := range c.definitions(def.Name) {
+			if i := slices.Index(d.Types, name); i >= 0 {
+				d.Types = slices.Delete(d.Types, i, i+1)
+				removed = true
+			}
+			if i := slices.Index(d.Interfaces, name); i >= 0 {
+				d.Interfaces = slices.Delete(d.Interfaces, i, i+1)
+				removed = true
+			}
+		}
+		return removed
+	}
+	return false
+}
+
+// removeInterface stops a type implementing an interface.
+func (c *schemaChecker) removeInterface(typeName, interfaceName string) bool {
+	removed := false
+	for _, def := range c.definitions(typeName) {
+		if i := slices.Index(def.Interfaces, interfaceName); i >= 0 {
+			def.Interfaces = slices.Delete(def.Interfaces, i, i+1)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// replaceFieldType recovers from a field of the wrong kind of type by making
+// it a String.
+func replaceFieldType(c *schemaChecker, pos SourceLocation, match []string) bool {
+	owner := c.fieldOwnerAt(pos)
+	if owner == nil {
+		return false
+	}
+	field := owner.Fields[slices.IndexFunc(owner.Fields, func(f *ast.FieldDefinition) bool { return samePosition(f.Position, pos) })]
+	field.Type = ast.NamedType("String", field.Type.Position)
+	return true
+}
+
+// directiveLists returns every list of directives applied in the schema.
+func (c *schemaChecker) directiveLists() []*ast.DirectiveList {
+	var lists []*ast.DirectiveList
+	for _, def := range append(slices.Clone(c.doc.Definitions), c.doc.Extensions...) {
+		lists = append(lists, &def.Directives)
+		for _, field := range def.Fields {
+			lists = append(lists, &field.Directives)
+		}
+		for _, value := range def.EnumValues {
+			lists = append(lists, &value.Directives)
+		}
+	}
+	for _, args := range c.argumentLists() {
+		for _, arg := range *args {
+			lists = append(lists, &arg.Directives)
+		}
+	}
+	for _, def := range append(slices.Clone(c.doc.Schema), c.doc.SchemaExtension...) {
+		lists = append(lists, &def.Directives)
+	}
+	return lists
+}
+
+// removeDirectiveAt removes the directive applied at pos.
+func (c *schemaChecker) removeDirectiveAt(pos SourceLocation) bool {
+	for _, list := range c.directiveLists() {
+		if deleteAt(list, pos, func(d *ast.Directive) *ast.Position { return d.Position }) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneSchemaDocument copies the definitions of a schema document, which
+// validation adds the fields, interfaces and values of extensions to.
+func cloneSchemaDocument(doc *ast.SchemaDocument) *ast.SchemaDocument {
+	clone := *doc
+	clone.Definitions = make(ast.DefinitionList, len(doc.Definitions))
+	for i, def := range doc.Definitions {
+		copied := *def
+		copied.Directives = slices.Clone(def.Directives)
+		copied.Interfaces = slices.Clone(def.Interfaces)
+		copied.Fields = slices.Clone(def.Fields)
+		copied.Types = slices.Clone(def.Types)
+		copied.EnumValues = slices.Clone(def.EnumValues)
+		clone.Definitions[i] = &copied
+	}
+	return &clone
+}
+
+// toSchemaError classifies a gqlparser error, with the names it could have
+// meant to use.
+func (c *schemaChecker) toSchemaError(err *gqlerror.Error, rule string) (SchemaError, *schemaRule, []string) {
+	schemaErr := SchemaError{Message: err.Message, Rule: rule}
+	if len(err.Locations) > 0 {
+		file, _ := err.Extensions["file"].(string)
+		schemaErr.Location = &SourceLocation{File: file, Line: err.Locations[0].Line, Column: err.Locations[0].Column}
+	}
+	if rule != "" {
+		return schemaErr, nil, nil
+	}
+
+	for i, r := range schemaRules {
+		match := r.message.FindStringSubmatch(err.Message)
+		if match == nil {
+			continue
+		}
+		schemaErr.Rule = r.name
+		if r.suggest != nil {
+			schemaErr.Suggestion = findClosest(match[1], r.suggest(c, match))
+		}
+		return schemaErr, &schemaRules[i], match
+	}
+	return schemaErr, nil, nil
+}
+
+// checkSchema parses and validates schema sources, federated adding the
+// federation definitions they are missing, and returns every error in them.
+// Syntax errors are returned without validating the schema.
+func checkSchema(sources []*ast.Source, federated bool) (*ast.Schema, []SchemaError) {
+	c := &schemaChecker{doc: &ast.SchemaDocument{}}
+
+	var errs []SchemaError
+	user := &ast.SchemaDocument{}
+	for _, source := range sources {
+		doc, err := parser.ParseSchema(source)
+		if err != nil {
+			schemaErr, _, _ := c.toSchemaError(gqlerror.WrapIfUnwrapped(err), "Syntax")
+			errs = append(errs, schemaErr)
+			continue
+		}
+		user.Merge(doc)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	prelude, err := parser.ParseSchema(validator.Prelude)
+	if err != nil {
+		panic(err)
+	}
+	c.doc.Merge(prelude)
+	c.doc.Merge(user)
+	if federated {
+		doc, err := parser.ParseSchema(federationSource(user))
+		if err != nil {
+			panic(err)
+		}
+		c.doc.Merge(doc)
+	}
+
+	var last *gqlerror.Error
+	for len(errs) < maxSchemaErrors {
+		schema, err := validator.ValidateSchemaDocument(cloneSchemaDocument(c.doc))
+		if err == nil {
+			if len(errs) == 0 {
+				return schema, nil
+			}
+			break
+		}
+
+		gqlErr := gqlerror.WrapIfUnwrapped(err)
+		if last != nil && gqlErr.Error() == last.Error() {
+			// Recovering didn't fix it, so it would be reported forever
+			break
+		}
+		last = gqlErr
+
+		schemaErr, rule, match := c.toSchemaError(gqlErr, "")
+		errs = append(errs, schemaErr)
+		if rule == nil || rule.recover == nil || schemaErr.Location == nil || !rule.recover(c, *schemaErr.Location, match) {
+			break
+		}
+	}
+
+	// Errors are found type by type, so they're sorted to follow the sources
+	order := slices.Collect(pluck(sources, func(s *ast.Source) string { return s.Name }))
+	slices.SortStableFunc(errs, func(a, b SchemaError) int {
+		if a.Location == nil || b.Location == nil {
+			return 0
+		}
+		if a.Location.File != b.Location.File {
+			return slices.Index(order, a.Location.File) - slices.Index(order, b.Location.File)
+		}
+		if a.Location.Line != b.Location.Line {
+			return a.Location.Line - b.Location.Line
+		}
+		return a.Location.Column - b.Location.Column
+	})
+	return nil, errs
+}
+
+// nameLength returns the length of the name starting at column, for
+// underlining it, or 1 if there is none.
+func nameLength(line string, column int) int {
+	if column < 1 || column > len(line) {
+		return 1
+	}
+	length := 0
+	for _, r := range line[column-1:] {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			break
+		}
+		length++
+	}
+	return max(length, 1)
+}
+
+func errorCount(n int) string {
+	if n == 1 {
+		return "1 error"
+	}
+	return fmt.Sprintf("%d errors", n)
+}
+
+// formatSchemaErrors renders schema errors like gqlx validate does, with a
+// snippet of the source each one is in.
+func formatSchemaErrors(errs []SchemaError, sources []*ast.Source) string {
+	var output string
+	for _, err := range errs {
+		if err.Location == nil {
+			output += fmt.Sprintf("  %s (%s)\n", err.Message, err.Rule)
+			continue
+		}
+
+		loc := err.Location
+		output += diagnostic.RenderLocation(loc.File, loc.Line, loc.Column) + " (" + err.Rule + ")\n"
+		var lines []string
+		if i := slices.IndexFunc(sources, func(s *ast.Source) bool { return s.Name == loc.File }); i >= 0 {
+			lines = strings.Split(sources[i].Input, "\n")
+		}
+		if loc.Line > 0 && loc.Line <= len(lines) {
+			line := lines[loc.Line-1]
+			output += diagnostic.RenderSnippet(line, loc.Line, loc.Column, nameLength(line, loc.Column), err.Message) + "\n"
+		} else {
+			// Without the source line, the message goes on its own
+			output += fmt.Sprintf("  %s\n", err.Message)
+		}
+		if err.Suggestion != "" {
+			output += fmt.Sprintf("  = help: did you mean `%s`?\n", err.Suggestion)
+		}
+	}
+	return output
+}
+
+// sourceName names a schema source after its file: the path relative to the
+// config file for schemas from the config, or the file name otherwise.
+func sourceName(path string) string {
+	if activeProject != nil && len(configSchemaPaths) > 0 {
+		if abs, err := filepath.Abs(path); err == nil {
+			if rel, err := filepath.Rel(activeProject.dir, abs); err == nil {
+				return rel
+			}
+		}
+	}
+	return filepath.Base(path)
+}
+
+// readSchemaSources reads schema files as sources named after their files.
+func readSchemaSources(paths []string) ([]*ast.Source, error) {
+	var sources []*ast.Source
+	for _, schemaPath := range paths {
+		path, err := filepath.Abs(schemaPath)
+		if err != nil {
+			return nil, err
+		}
+
+		bytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &ast.Source{
+			Input: string(bytes),
+			Name:  sourceName(path),
+		})
+	}
+	return sources, nil
+}
+
+func runCheckSchema(cmd *cobra.Command, args []string) error {
+	var sources []*ast.Source
+	var errs []SchemaError
+	if len(subgraphPaths) > 0 {
+		for _, path := range subgraphPaths {
+			subgraphSources, err := readSchemaSources([]string{path})
+			if err != nil {
+				if errors.Is(err, os.ErrNotExist) {
+					return fmt.Errorf("subgraph file does not exist: %s", path)
+				}
+				return err
+			}
+			_, subgraphErrs := checkSchema(subgraphSources, true)
+			sources = append(sources, subgraphSources...)
+			errs = append(errs, subgraphErrs...)
+		}
+	} else {
+		var err error
+		sources, err = readSchemaSources(schemaPaths())
+		if err != nil {
+			// Reports missing files the way other commands do
+			_, err = loadCliForSchema()
+			return err
+		}
+		_, errs = checkSchema(sources, federation)
+	}
+
+	result := SchemaCheckResult{Valid: len(errs) == 0, Errors: errs}
+	if outputFormat == render.FormatJSON {
+		bytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+	} else if result.Valid {
+		fmt.Fprintln(cmd.OutOrStdout(), "✓ Schema is valid")
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "✗ Schema has %s:\n%s", errorCount(len(errs)), formatSchemaErrors(errs, sources))
+	}
+
+	if !result.Valid {
+		// The errors are the error message
+		cmd.SilenceErrors = true
+		return ErrValidationFailed
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestFormatSchemaErrors_WithoutSourceLine(t *testing.T) {
+	errs := []SchemaError{{
+		Message:  "Undefined type Foo.",
+		Location: &SourceLocation{File: "missing.graphql", Line: 3, Column: 9},
+		Rule:     "KnownTypeNames",
+	}}
+	sources := []*ast.Source{{Name: "schema.graphql", Input: "type Query { a: Foo }"}}
+
+	output := formatSchemaErrors(errs, sources)
+	assert.Contains(t, output, "missing.graphql:3:9 (KnownTypeNames)\n")
+	assert.Contains(t, output, "  Undefined type Foo.\n")
+}
@@ -0,0 +1,173 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const brokenSchema = `type Query {
+  user(id: ID!): Usr
+  node(id: ID!): Node
+}
+
+interface Node {
+  id: ID!
+}
+
+type User implements Nod {
+  id: ID!
+  name: String @deprecatd
+  name: String
+}
+
+type Post implements Node {
+  id: String
+  author(filter: User): User
+}
+
+union Result = User | Strng
+
+type User {
+  id: ID!
+}
+`
+
+type schemaCheck struct {
+	Valid  bool `json:"valid"`
+	Errors []struct {
+		Message  string `json:"message"`
+		Location struct {
+			File   string `json:"file"`
+			Line   int    `json:"line"`
+			Column int    `json:"column"`
+		} `json:"location"`
+		Rule       string `json:"rule"`
+		Suggestion string `json:"suggestion"`
+	} `json:"errors"`
+}
+
+func TestCheckSchema_Valid(t *testing.T) {
+	schemaPath := setupTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "✓ Schema is valid\n", stdout)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"check-schema", "-s", schemaPath, "-f", "json"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"valid": true}`, stdout)
+}
+
+func TestCheckSchema_ReportsEveryError(t *testing.T) {
+	schemaPath := writeTestSchema(t, brokenSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "-s", schemaPath, "-f", "json"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+
+	var result schemaCheck
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.False(t, result.Valid)
+
+	var rules, suggestions []string
+	var lines []int
+	for _, e := range result.Errors {
+		assert.Equal(t, "schema.graphql", e.Location.File)
+		rules = append(rules, e.Rule)
+		lines = append(lines, e.Location.Line)
+		suggestions = append(suggestions, e.Suggestion)
+	}
+	assert.Equal(t, []string{
+		"KnownTypeNames",
+		"KnownTypeNames",
+		"KnownDirectives",
+		"UniqueFieldNames",
+		"InterfaceImplementation",
+		"ArgumentInputTypes",
+		"KnownTypeNames",
+		"UniqueTypeNames",
+	}, rules)
+	assert.Equal(t, []int{2, 10, 12, 13, 17, 18, 21, 23}, lines)
+	assert.Equal(t, []string{"User", "Node", "deprecated", "", "", "", "String", ""}, suggestions)
+
+	first := result.Errors[0]
+	assert.Equal(t, "Undefined type Usr.", first.Message)
+	assert.Equal(t, 18, first.Location.Column)
+}
+
+func TestCheckSchema_Text(t *testing.T) {
+	schemaPath := writeTestSchema(t, brokenSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "-s", schemaPath, "-f", "text"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+	assert.Contains(t, stdout, "✗ Schema has 8 errors:\n")
+	assert.Contains(t, stdout, "--> schema.graphql:2:18 (KnownTypeNames)\n"+
+		"2 |   user(id: ID!): Usr\n"+
+		"  |                  ^^^ Undefined type Usr.\n"+
+		"  = help: did you mean `User`?\n")
+	assert.Contains(t, stdout, "12 |   name: String @deprecatd\n"+
+		"   |                 ^^^^^^^^^ Undefined directive deprecatd.\n")
+}
+
+func TestCheckSchema_SyntaxErrorInEachFile(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": "schema: [a.graphql, b.graphql, c.graphql]\n",
+		"a.graphql":          "type Query {\n  a: Int\n",
+		"b.graphql":          "type B { b: Int }\n",
+		"c.graphql":          "type C {\n  c: Int!!\n}\n",
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "-f", "json"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+
+	var result schemaCheck
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "a.graphql", result.Errors[0].Location.File)
+	assert.Equal(t, "Syntax", result.Errors[0].Rule)
+	assert.Equal(t, "c.graphql", result.Errors[1].Location.File)
+	assert.Equal(t, 2, result.Errors[1].Location.Line)
+}
+
+func TestCheckSchema_Subgraphs(t *testing.T) {
+	paths := setupSubgraphs(t,
+		[2]string{"accounts", "type Query { me: User }\ntype User @key(fields: \"id\") { id: ID! }\n"},
+		[2]string{"products", "type Product @kye(fields: \"upc\") { upc: String! }\n"},
+	)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "--subgraph", paths[0], "--subgraph", paths[1], "-f", "json"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+
+	var result schemaCheck
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "products.graphql", result.Errors[0].Location.File)
+	assert.Equal(t, "key", result.Errors[0].Suggestion)
+}
+
+func TestSchemaErrors_OtherCommands(t *testing.T) {
+	schemaPath := writeTestSchema(t, brokenSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GraphQL schema parsing error (8 errors):\n--> schema.graphql:2:18 (KnownTypeNames)\n")
+	assert.Contains(t, err.Error(), "did you mean `Node`?")
+	assert.Contains(t, err.Error(), "Cannot redeclare type User.")
+}
+
+func TestCheckSchema_SameFileNameInTwoDirectories(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": "schema: \"**/schema.graphql\"\n",
+		"a/schema.graphql":   "type Query {\n  a: Int\n}\n",
+		"b/schema.graphql":   "type B {\n  b: Strng\n}\n",
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"check-schema", "-f", "text"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+	assert.Contains(t, stdout, "--> b/schema.graphql:2:6 (KnownTypeNames)\n"+
+		"2 |   b: Strng\n"+
+		"  |      ^^^^^ Undefined type Strng.\n")
+}
@@ -0,0 +1,190 @@
+package cmd_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertValidGo checks that the generated code parses as a Go file.
+func assertValidGo(t *testing.T, source string) {
+	t.Helper()
+	_, err := parser.ParseFile(token.NewFileSet(), "generated.go", source, parser.AllErrors)
+	require.NoError(t, err, "generated code should parse:\n%s", source)
+}
+
+func TestCodegenGo_SimpleQuery(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me {
+			me {
+				id
+				name
+				createdAt
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+
+	assert.Contains(t, stdout, "// Code generated by gqlx. DO NOT EDIT.")
+	assert.Contains(t, stdout, "package graphql")
+	assert.Contains(t, stdout, "type MeResponse struct {\n\tMe *MeResponseMe `json:\"me\"`\n}")
+	assert.Contains(t, stdout, "\tID        string          `json:\"id\"`")
+	assert.Contains(t, stdout, "\tName      *string         `json:\"name\"`")
+	// Unmapped custom scalars are kept as raw JSON
+	assert.Contains(t, stdout, "\tCreatedAt json.RawMessage `json:\"createdAt\"`")
+	assert.Contains(t, stdout, "type MeVariables struct{}")
+	assert.Contains(t, stdout, "func Me(ctx context.Context, client *http.Client, endpoint string, variables MeVariables) (*MeResponse, error) {")
+	assert.Contains(t, stdout, "const meDocument = `query Me {")
+}
+
+func TestCodegenGo_AliasesListsAndEnums(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Friends {
+			viewer: me {
+				role
+				friends {
+					displayName: name
+				}
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+
+	assert.Contains(t, stdout, "Viewer *FriendsResponseViewer `json:\"viewer\"`")
+	assert.Contains(t, stdout, "Friends []FriendsResponseViewerFriends `json:\"friends\"`")
+	assert.Contains(t, stdout, "DisplayName *string `json:\"displayName\"`")
+	assert.Contains(t, stdout, "type Role string")
+	assert.Contains(t, stdout, "RoleAdmin  Role = \"ADMIN\"")
+	assert.Contains(t, stdout, "RoleMember Role = \"MEMBER\"")
+}
+
+func TestCodegenGo_AbstractTypesMergeFields(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Search($filter: SearchFilter!) {
+			search(filter: $filter) {
+				__typename
+				...UserBits
+				... on Post {
+					title
+				}
+			}
+		}
+
+		fragment UserBits on User {
+			id
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+
+	assert.Contains(t, stdout, "type SearchResponseSearch struct {")
+	assert.Contains(t, stdout, "Typename string  `json:\"__typename\"`")
+	// Fields selected on only some possible types become optional
+	assert.Contains(t, stdout, "ID       *string `json:\"id\"`")
+	assert.Contains(t, stdout, "Title    *string `json:\"title\"`")
+	// The document includes the fragments the operation uses
+	assert.Contains(t, stdout, "fragment UserBits on User {")
+}
+
+func TestCodegenGo_VariablesAndInputTypes(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Search($filter: SearchFilter!, $first: Int) {
+			search(filter: $filter, first: $first) {
+				__typename
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+
+	assert.Contains(t, stdout, "type SearchVariables struct {\n\tFilter SearchFilter `json:\"filter\"`\n\tFirst  *int32       `json:\"first,omitempty\"`\n}")
+	assert.Contains(t, stdout, "type SearchFilter struct {")
+	assert.Contains(t, stdout, "Term  string        `json:\"term\"`")
+	assert.Contains(t, stdout, "Next  *SearchFilter `json:\"next,omitempty\"`")
+}
+
+func TestCodegenGo_DefaultsAreOmittedWhenUnset(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Search($filter: SearchFilter!, $first: Int! = 20) {
+			search(filter: $filter, first: $first) {
+				__typename
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+
+	// A nil pointer lets the server apply the default instead of sending 0
+	assert.Contains(t, stdout, "First  *int32       `json:\"first,omitempty\"`")
+	assert.Contains(t, stdout, "Page  *int32        `json:\"page,omitempty\"`")
+}
+
+func TestCodegenGo_ScalarMappingWithImport(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me {
+			me {
+				createdAt
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath, "--scalar", "DateTime=time.Time"})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+	assert.Contains(t, stdout, "\t\"time\"\n")
+	assert.Contains(t, stdout, "CreatedAt *time.Time `json:\"createdAt\"`")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath, "--scalar", "DateTime=github.com/example/civil.DateTime"})
+	require.NoError(t, err)
+	assertValidGo(t, stdout)
+	assert.Contains(t, stdout, "\t\"github.com/example/civil\"\n")
+	assert.Contains(t, stdout, "CreatedAt *civil.DateTime `json:\"createdAt\"`")
+}
+
+func TestCodegenGo_PackageAndOutputFile(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `query Me { me { id } }`)
+	outputPath := filepath.Join(t.TempDir(), "generated.go")
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath, "--package", "userapi", "-o", outputPath})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "package userapi")
+}
+
+func TestCodegenGo_InvalidQuery(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `query Me { me { nme } }`)
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not valid against the schema")
+	assert.Contains(t, stderr, "did you mean `name`?")
+}
+
+func TestCodegenGo_AnonymousOperation(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `{ me { id } }`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"codegen", "go", queryPath, "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations must be named")
+}
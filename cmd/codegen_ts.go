@@ -0,0 +1,359 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type codegenTSOptions struct {
+	scalarsConfig string
+	scalars       []string
+	output        string
+}
+
+// builtinScalarTSTypes maps the built-in GraphQL scalars to TypeScript types.
+var builtinScalarTSTypes = map[string]string{
+	"ID":      "string",
+	"String":  "string",
+	"Int":     "number",
+	"Float":   "number",
+	"Boolean": "boolean",
+}
+
+// tsTypenamePlaceholder stands in for the __typename literal while rendering
+// the object shape of a concrete type, so that identical shapes of different
+// types can be merged.
+const tsTypenamePlaceholder = "\x00typename\x00"
+
+const tsIndent = "  "
+
+// tsGenerator renders TypeScript types for operations, collecting the enums and
+// input objects they reference so they can be emitted once.
+type tsGenerator struct {
+	schema  *ast.Schema
+	scalars map[string]string
+	enums   map[string]bool
+	inputs  map[string]bool
+}
+
+func newTSGenerator(schema *ast.Schema, scalars map[string]string) *tsGenerator {
+	return &tsGenerator{
+		schema:  schema,
+		scalars: scalars,
+		enums:   map[string]bool{},
+		inputs:  map[string]bool{},
+	}
+}
+
+// scalarType returns the TypeScript type for a scalar, defaulting to unknown
+// for custom scalars without a mapping.
+func (g *tsGenerator) scalarType(name string) string {
+	if mapped, ok := g.scalars[name]; ok {
+		return mapped
+	}
+	if builtin, ok := builtinScalarTSTypes[name]; ok {
+		return builtin
+	}
+	return "unknown"
+}
+
+// wrapType applies GraphQL list and nullability wrappers to a rendered named type.
+func wrapType(t *ast.Type, named func() string) string {
+	var inner string
+	if t.Elem != nil {
+		inner = "Array<" + wrapType(t.Elem, named) + ">"
+	} else {
+		inner = named()
+	}
+	if t.NonNull {
+		return inner
+	}
+	return inner + " | null"
+}
+
+// outputType renders the type of a selected field.
+func (g *tsGenerator) outputType(t *ast.Type, selectionSet ast.SelectionSet, depth int) string {
+	return wrapType(t, func() string {
+		def := g.schema.Types[t.Name()]
+		switch def.Kind {
+		case ast.Scalar:
+			return g.scalarType(def.Name)
+		case ast.Enum:
+			g.enums[def.Name] = true
+			return def.Name
+		default:
+			return g.selectionType(def, selectionSet, depth)
+		}
+	})
+}
+
+// selectionType renders the object type produced by a selection set on a
+// composite type. For interfaces and unions this is a union over the possible
+// object types, where types with identical shapes share a single member.
+func (g *tsGenerator) selectionType(def *ast.Definition, selectionSet ast.SelectionSet, depth int) string {
+	var shapes []string
+	typenames := map[string][]string{}
+
+	for _, object := range concreteTypes(g.schema, def) {
+		shape := g.objectShape(object, selectionSet, depth)
+		if _, ok := typenames[shape]; !ok {
+			shapes = append(shapes, shape)
+		}
+		typenames[shape] = append(typenames[shape], "'"+object.Name+"'")
+	}
+
+	if len(shapes) == 0 {
+		return "never"
+	}
+
+	var members []string
+	for _, shape := range shapes {
+		members = append(members, strings.ReplaceAll(shape, tsTypenamePlaceholder, strings.Join(typenames[shape], " | ")))
+	}
+	if len(members) == 1 {
+		return members[0]
+	}
+	// Parenthesise members so that the union binds tighter than the
+	// nullability and list wrappers applied around it
+	return "(" + strings.Join(members, " | ") + ")"
+}
+
+// objectShape renders the fields selected on a concrete object type.
+func (g *tsGenerator) objectShape(object *ast.Definition, selectionSet ast.SelectionSet, depth int) string {
+	fields := collectFields(g.schema, selectionSet, object)
+	if len(fields) == 0 {
+		return "{}"
+	}
+
+	indent := strings.Repeat(tsIndent, depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, field := range fields {
+		var typ string
+		if field.Fields[0].Name == "__typename" {
+			typ = tsTypenamePlaceholder
+		} else {
+			def := object.Fields.ForName(field.Fields[0].Name)
+			typ = g.outputType(def.Type, field.SelectionSet(), depth+1)
+		}
+		// Fields under @skip or @include are left out of the response when
+		// the condition removes them
+		optional := ""
+		if field.Conditional {
+			optional = "?"
+		}
+		fmt.Fprintf(&b, "%s%s%s: %s;\n", indent, field.ResponseKey, optional, typ)
+	}
+	b.WriteString(strings.Repeat(tsIndent, depth) + "}")
+	return b.String()
+}
+
+// inputType renders the type of a variable or input object field, recording
+// the enums and input objects it references.
+func (g *tsGenerator) inputType(t *ast.Type) string {
+	return wrapType(t, func() string {
+		def := g.schema.Types[t.Name()]
+		switch def.Kind {
+		case ast.Enum:
+			g.enums[def.Name] = true
+		case ast.InputObject:
+			if !g.inputs[def.Name] {
+				g.inputs[def.Name] = true
+				for _, field := range def.Fields {
+					g.inputType(field.Type)
+				}
+			}
+		default:
+			return g.scalarType(def.Name)
+		}
+		return def.Name
+	})
+}
+
+// inputProperty renders an object property for a variable or input field.
+// Nullable values and values with defaults may be omitted.
+func (g *tsGenerator) inputProperty(name string, t *ast.Type, defaultValue *ast.Value) string {
+	optional := ""
+	if !t.NonNull || defaultValue != nil {
+		optional = "?"
+	}
+	return fmt.Sprintf("%s%s%s: %s;\n", tsIndent, name, optional, g.inputType(t))
+}
+
+func (g *tsGenerator) variablesType(op *ast.OperationDefinition) string {
+	if len(op.VariableDefinitions) == 0 {
+		return "Record<string, never>"
+	}
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, v := range op.VariableDefinitions {
+		b.WriteString(g.inputProperty(v.Variable, v.Type, v.DefaultValue))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// declarations renders the enums and input objects referenced so far.
+func (g *tsGenerator) declarations() string {
+	var b strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(g.enums)) {
+		var values []string
+		for _, value := range g.schema.Types[name].EnumValues {
+			values = append(values, "'"+value.Name+"'")
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n\n", name, strings.Join(values, " | "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(g.inputs)) {
+		def := g.schema.Types[name]
+		fmt.Fprintf(&b, "export type %s = {\n", name)
+		for _, field := range def.Fields {
+			b.WriteString(g.inputProperty(field.Name, field.Type, field.DefaultValue))
+		}
+		b.WriteString("};\n\n")
+	}
+	return b.String()
+}
+
+// generateTS renders result and variables types for every operation, and a
+// type for every named fragment, in the given documents.
+func generateTS(schema *ast.Schema, docs []*ast.QueryDocument, scalars map[string]string) (string, error) {
+	g := newTSGenerator(schema, scalars)
+	seen := map[string]bool{}
+	declare := func(name string) error {
+		if seen[name] {
+			return fmt.Errorf("duplicate generated type name '%s'", name)
+		}
+		seen[name] = true
+		return nil
+	}
+
+	var body strings.Builder
+	for _, doc := range docs {
+		for _, op := range doc.Operations {
+			if op.Name == "" {
+				return "", fmt.Errorf("operations must be named for code generation")
+			}
+			typeName := operationTypeName(op.Name, operationKindName(op))
+			variablesName := typeName + "Variables"
+			if err := declare(typeName); err != nil {
+				return "", err
+			}
+			if err := declare(variablesName); err != nil {
+				return "", err
+			}
+
+			root := operationRootType(schema, op)
+			fmt.Fprintf(&body, "export type %s = %s;\n\n", typeName, g.selectionType(root, op.SelectionSet, 0))
+			fmt.Fprintf(&body, "export type %s = %s;\n\n", variablesName, g.variablesType(op))
+		}
+
+		for _, fragment := range doc.Fragments {
+			typeName := operationTypeName(fragment.Name, "Fragment")
+			if err := declare(typeName); err != nil {
+				return "", err
+			}
+			def := schema.Types[fragment.TypeCondition]
+			fmt.Fprintf(&body, "export type %s = %s;\n\n", typeName, g.selectionType(def, fragment.SelectionSet, 0))
+		}
+	}
+
+	for name := range g.enums {
+		if err := declare(name); err != nil {
+			return "", err
+		}
+	}
+	for name := range g.inputs {
+		if err := declare(name); err != nil {
+			return "", err
+		}
+	}
+
+	output := "// " + generatedHeader + "\n\n" + g.declarations() + body.String()
+	return strings.TrimRight(output, "\n") + "\n", nil
+}
+
+func NewCodegenTSCmd() *cobra.Command {
+	opts := &codegenTSOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "ts <query files...>",
+		Short: "Generates TypeScript types for operations",
+		Long: `Generates TypeScript result and variables types for each named operation.
+
+For an operation "query GetUser($id: ID!)", two types are generated:
+GetUserQuery (the shape of the response data) and GetUserQueryVariables.
+Named fragments get a type too, e.g. UserFieldsFragment.
+
+Result types follow the selection sets exactly: aliases become property names,
+fragments are inlined, and __typename is typed as a string literal. Selections
+on interfaces and unions become a union over the possible object types.
+
+Enums become string literal unions, and input objects used by variables are
+emitted as object types. Custom scalars are typed as unknown unless mapped
+with --scalar or --scalars.
+
+The --format flag is ignored; output is always TypeScript.`,
+		Example: `  # Generate types for a query
+  gqlx codegen ts queries/user.graphql > src/generated/user.ts
+
+  # Generate types for every operation in a directory
+  gqlx codegen ts queries/*.graphql -o src/generated/graphql.ts
+
+  # Map custom scalars to TypeScript types
+  gqlx codegen ts query.graphql --scalar DateTime=string --scalar JSON=unknown
+
+  # Map custom scalars from a JSON config file
+  gqlx codegen ts query.graphql --scalars scalars.json`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCodegenTS(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.scalarsConfig, "scalars", "", "JSON file mapping custom scalars to TypeScript types")
+	cmd.Flags().StringArrayVar(&opts.scalars, "scalar", nil, "Map a custom scalar to a TypeScript type, as Name=Type (can be specified multiple times)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the generated code to a file instead of stdout")
+
+	return cmd
+}
+
+func runCodegenTS(cmd *cobra.Command, args []string, opts *codegenTSOptions) error {
+	scalars, err := loadScalarMapping(opts.scalarsConfig, opts.scalars)
+	if err != nil {
+		return err
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+	if err := validateScalarMapping(schema, scalars); err != nil {
+		return err
+	}
+
+	docs, err := loadOperationFiles(cmd, schema, args)
+	if err != nil {
+		return err
+	}
+
+	output, err := generateTS(schema, docs, scalars)
+	if err != nil {
+		return err
+	}
+
+	if opts.output != "" {
+		return os.WriteFile(opts.output, []byte(output), 0644)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
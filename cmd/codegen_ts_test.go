@@ -0,0 +1,352 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codegenTestSchema = `
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	role: Role!
+	friends: [User!]!
+	createdAt: DateTime
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+}
+
+union SearchResult = User | Post
+
+enum Role {
+	ADMIN
+	MEMBER
+}
+
+scalar DateTime
+
+input SearchFilter {
+	term: String!
+	role: Role
+	limit: Int = 10
+	page: Int! = 1
+	next: SearchFilter
+}
+
+type Query {
+	me: User
+	node(id: ID!): Node
+	search(filter: SearchFilter!, first: Int, after: String): [SearchResult!]!
+}
+
+type Mutation {
+	rename(id: ID!, name: String!): User!
+}
+`
+
+func setupCodegenTest(t *testing.T, query string) (schemaPath string, queryPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	schemaPath = filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(codegenTestSchema), 0644))
+	queryPath = filepath.Join(dir, "query.graphql")
+	require.NoError(t, os.WriteFile(queryPath, []byte(query), 0644))
+	return schemaPath, queryPath
+}
+
+func TestCodegenTS_SimpleQuery(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me {
+			me {
+				id
+				name
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "// Code generated by gqlx. DO NOT EDIT.")
+	assert.Contains(t, stdout, `export type MeQuery = {
+  me: {
+    id: string;
+    name: string | null;
+  } | null;
+};`)
+	assert.Contains(t, stdout, "export type MeQueryVariables = Record<string, never>;")
+}
+
+func TestCodegenTS_AliasesListsAndEnums(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Friends {
+			viewer: me {
+				role
+				friends {
+					displayName: name
+				}
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "export type Role = 'ADMIN' | 'MEMBER';")
+	assert.Contains(t, stdout, `export type FriendsQuery = {
+  viewer: {
+    role: Role;
+    friends: Array<{
+      displayName: string | null;
+    }>;
+  } | null;
+};`)
+}
+
+func TestCodegenTS_ConditionalFieldsAreOptional(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me($brief: Boolean!) {
+			me {
+				id
+				name @skip(if: $brief)
+				... on User @include(if: $brief) {
+					role
+				}
+				createdAt
+				createdAt @skip(if: $brief)
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	// A field that is also selected without a condition is always present
+	assert.Contains(t, stdout, `export type MeQuery = {
+  me: {
+    id: string;
+    name?: string | null;
+    role?: Role;
+    createdAt: unknown | null;
+  } | null;
+};`)
+}
+
+func TestCodegenTS_InterfaceAndTypename(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query GetNode($id: ID!) {
+			node(id: $id) {
+				__typename
+				id
+				... on User {
+					name
+				}
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, `export type GetNodeQuery = {
+  node: ({
+    __typename: 'Post';
+    id: string;
+  } | {
+    __typename: 'User';
+    id: string;
+    name: string | null;
+  }) | null;
+};`)
+	assert.Contains(t, stdout, `export type GetNodeQueryVariables = {
+  id: string;
+};`)
+}
+
+func TestCodegenTS_IdenticalShapesAreMerged(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query GetNodeId {
+			node(id: "1") {
+				__typename
+				id
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, `  node: {
+    __typename: 'Post' | 'User';
+    id: string;
+  } | null;`)
+}
+
+func TestCodegenTS_UnionWithFragments(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Search($filter: SearchFilter!) {
+			search(filter: $filter) {
+				...UserBits
+				... on Post {
+					title
+				}
+			}
+		}
+
+		fragment UserBits on User {
+			id
+			createdAt
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, `  search: Array<({
+    title: string;
+  } | {
+    id: string;
+    createdAt: unknown | null;
+  })>;`)
+	assert.Contains(t, stdout, `export type UserBitsFragment = {
+  id: string;
+  createdAt: unknown | null;
+};`)
+}
+
+func TestCodegenTS_VariablesAndInputTypes(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Search($filter: SearchFilter!, $first: Int = 10, $after: String) {
+			search(filter: $filter, first: $first, after: $after) {
+				__typename
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, `export type SearchQueryVariables = {
+  filter: SearchFilter;
+  first?: number | null;
+  after?: string | null;
+};`)
+	assert.Contains(t, stdout, `export type SearchFilter = {
+  term: string;
+  role?: Role | null;
+  limit?: number | null;
+  page?: number;
+  next?: SearchFilter | null;
+};`)
+	assert.Contains(t, stdout, "export type Role = 'ADMIN' | 'MEMBER';")
+}
+
+func TestCodegenTS_MutationSuffix(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		mutation RenameUserMutation($id: ID!, $name: String!) {
+			rename(id: $id, name: $name) {
+				id
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "export type RenameUserMutation = {")
+	assert.Contains(t, stdout, "export type RenameUserMutationVariables = {")
+}
+
+func TestCodegenTS_ScalarMapping(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me {
+			me {
+				createdAt
+			}
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "--scalar", "DateTime=string"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "createdAt: string | null;")
+
+	configPath := filepath.Join(t.TempDir(), "scalars.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"DateTime": "Date"}`), 0644))
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "--scalars", configPath})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "createdAt: Date | null;")
+}
+
+func TestCodegenTS_ScalarMappingErrors(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `query Me { me { id } }`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "--scalar", "DateTime"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--scalar must be specified as Name=Type")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "--scalar", "DateTme=string"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'DateTime'")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "--scalar", "Role=string"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'Role' is not a scalar")
+}
+
+func TestCodegenTS_InvalidQuery(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `
+		query Me {
+			me {
+				nme
+			}
+		}
+	`)
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not valid against the schema")
+	assert.Contains(t, stderr, "Cannot query field")
+	assert.Contains(t, stderr, "did you mean `name`?")
+}
+
+func TestCodegenTS_AnonymousOperation(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `{ me { id } }`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations must be named")
+}
+
+func TestCodegenTS_DuplicateOperationNames(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `query Me { me { id } }`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, queryPath, "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate generated type name 'MeQuery'")
+}
+
+func TestCodegenTS_OutputFile(t *testing.T) {
+	schemaPath, queryPath := setupCodegenTest(t, `query Me { me { id } }`)
+	outputPath := filepath.Join(t.TempDir(), "generated.ts")
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"codegen", "ts", queryPath, "-s", schemaPath, "-o", outputPath})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "export type MeQuery = {")
+}
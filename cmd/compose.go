@@ -0,0 +1,586 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// ErrCompositionFailed is returned when subgraphs cannot be composed.
+var ErrCompositionFailed = errors.New("composition failed")
+
+type composeOptions struct {
+	output string
+}
+
+// Composition error codes, named after the ones Apollo's composition reports.
+const (
+	compositionTypeKindMismatch          = "TYPE_KIND_MISMATCH"
+	compositionFieldTypeMismatch         = "FIELD_TYPE_MISMATCH"
+	compositionArgumentTypeMismatch      = "FIELD_ARGUMENT_TYPE_MISMATCH"
+	compositionInvalidFieldSharing       = "INVALID_FIELD_SHARING"
+	compositionExternalMissingOnBase     = "EXTERNAL_MISSING_ON_BASE"
+	compositionKeyInvalidFields          = "KEY_INVALID_FIELDS"
+	compositionRequiredInputFieldMissing = "REQUIRED_INPUT_FIELD_MISSING_IN_SOME_SUBGRAPH"
+	compositionInvalidSupergraph         = "INVALID_SUPERGRAPH"
+)
+
+// compositionErrors is the error returned when loading a supergraph fails, so
+// every problem is reported rather than just the first.
+type compositionErrors []CompositionError
+
+func (errs compositionErrors) Error() string {
+	lines := []string{"subgraphs could not be composed:"}
+	for _, err := range errs {
+		lines = append(lines, "  "+formatCompositionErrorText(err))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatCompositionErrorText(err CompositionError) string {
+	return fmt.Sprintf("%s: %s", err.Code, err.Message)
+}
+
+func formatCompositionErrorsPretty(errs []CompositionError) string {
+	t := makeTable()
+
+	for _, err := range errs {
+		t.Row(err.Code, err.Message)
+	}
+	t.Headers("code", "message")
+
+	return t.String()
+}
+
+// subgraph is a loaded subgraph schema, named after its file.
+type subgraph struct {
+	name    string
+	schema  *ast.Schema
+	version int
+}
+
+func loadSubgraph(path string) (*subgraph, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("subgraph file does not exist: %s", path)
+		}
+		return nil, err
+	}
+
+	base := filepath.Base(path)
+	source := &ast.Source{Input: string(bytes), Name: sourceName(path)}
+	schema, errs := checkSchema([]*ast.Source{source}, true)
+	if len(errs) > 0 {
+		return nil, &schemaErrors{errors: errs, sources: []*ast.Source{source}}
+	}
+	doc, err := parser.ParseSchema(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subgraph{
+		name:    strings.TrimSuffix(base, filepath.Ext(base)),
+		schema:  schema,
+		version: federationVersion(doc),
+	}, nil
+}
+
+// federationNames returns the names of the types and directives federation
+// adds to subgraphs, which are left out of the supergraph.
+func federationNames() (types, directives map[string]bool) {
+	types = map[string]bool{"_Entity": true}
+	directives = map[string]bool{}
+	for _, version := range []int{1, 2} {
+		for _, def := range federationDefinitions(version) {
+			if def.directive {
+				directives[def.name] = true
+			} else {
+				types[def.name] = true
+			}
+		}
+	}
+	return types, directives
+}
+
+// composer merges subgraphs into a supergraph schema document.
+type composer struct {
+	subgraphs            []*subgraph
+	federationTypes      map[string]bool
+	federationDirectives map[string]bool
+	errors               []CompositionError
+}
+
+// typeInSubgraph is a type's definition in one subgraph.
+type typeInSubgraph struct {
+	subgraph *subgraph
+	def      *ast.Definition
+}
+
+// fieldInSubgraph is a field's definition in one subgraph.
+type fieldInSubgraph struct {
+	typeInSubgraph
+	field *ast.FieldDefinition
+}
+
+func (c *composer) report(code, format string, args ...any) {
+	c.errors = append(c.errors, CompositionError{Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// directives returns the applied directives without the federation ones,
+// which only matter to subgraphs.
+func (c *composer) directives(lists ...ast.DirectiveList) ast.DirectiveList {
+	var result ast.DirectiveList
+	for _, list := range lists {
+		for _, directive := range list {
+			if !c.federationDirectives[directive.Name] && result.ForName(directive.Name) == nil {
+				result = append(result, directive)
+			}
+		}
+	}
+	return result
+}
+
+func hasDirective(directives ast.DirectiveList, name string) bool {
+	return directives.ForName(name) != nil || directives.ForName("federation__"+name) != nil
+}
+
+func subgraphNames(entries []fieldInSubgraph) string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.subgraph.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// keyFieldNames returns the top-level fields selected by a type's @key
+// directives in one subgraph.
+func keyFieldNames(def *ast.Definition) []string {
+	var names []string
+	for _, directive := range def.Directives {
+		if !isKeyDirective(directive) {
+			continue
+		}
+		if fields := directive.Arguments.ForName("fields"); fields != nil {
+			doc, err := parser.ParseQuery(&ast.Source{Input: "{" + fields.Value.Raw + "}"})
+			if err != nil || len(doc.Operations) != 1 {
+				continue
+			}
+			for _, selection := range doc.Operations[0].SelectionSet {
+				if field, ok := selection.(*ast.Field); ok {
+					names = append(names, field.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// isShareable reports whether a subgraph allows other subgraphs to resolve a
+// field too. Federation v1 subgraphs share every field; in v2 fields must be
+// @shareable, on a @shareable type, or part of a @key.
+func isShareable(entry fieldInSubgraph) bool {
+	return entry.subgraph.version == 1 ||
+		hasDirective(entry.field.Directives, "shareable") ||
+		hasDirective(entry.def.Directives, "shareable") ||
+		slices.Contains(keyFieldNames(entry.def), entry.field.Name)
+}
+
+// mergeTypes merges a field's types from two subgraphs when they only differ
+// in nullability. Output fields are nullable if nullable in any subgraph, and
+// input fields are non-null if non-null in any subgraph.
+func mergeTypes(a, b *ast.Type, input bool) (*ast.Type, bool) {
+	if (a.Elem == nil) != (b.Elem == nil) || a.NamedType != b.NamedType {
+		return nil, false
+	}
+	merged := &ast.Type{NamedType: a.NamedType, Position: a.Position}
+	if input {
+		merged.NonNull = a.NonNull || b.NonNull
+	} else {
+		merged.NonNull = a.NonNull && b.NonNull
+	}
+	if a.Elem != nil {
+		elem, ok := mergeTypes(a.Elem, b.Elem, input)
+		if !ok {
+			return nil, false
+		}
+		merged.Elem = elem
+	}
+	return merged, true
+}
+
+// mergeArguments returns the union of a field's arguments across subgraphs.
+func (c *composer) mergeArguments(coordinate string, entries []fieldInSubgraph) ast.ArgumentDefinitionList {
+	var merged ast.ArgumentDefinitionList
+	for _, entry := range entries {
+		for _, arg := range entry.field.Arguments {
+			existing := merged.ForName(arg.Name)
+			if existing == nil {
+				copied := *arg
+				copied.Directives = c.directives(arg.Directives)
+				merged = append(merged, &copied)
+				continue
+			}
+			if typeToString(existing.Type) != typeToString(arg.Type) {
+				c.report(compositionArgumentTypeMismatch, "Argument %s.%s has type %s in %s but %s in %s",
+					coordinate, arg.Name, typeToString(existing.Type), entries[0].subgraph.name, typeToString(arg.Type), entry.subgraph.name)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeField merges one field of an object or interface type across the
+// subgraphs that define it.
+func (c *composer) mergeField(kind ast.DefinitionKind, entries []fieldInSubgraph) *ast.FieldDefinition {
+	first := entries[0]
+	coordinate := first.def.Name + "." + first.field.Name
+
+	var resolving, nonShareable []fieldInSubgraph
+	for _, entry := range entries {
+		if hasDirective(entry.field.Directives, "external") {
+			continue
+		}
+		resolving = append(resolving, entry)
+		if !isShareable(entry) {
+			nonShareable = append(nonShareable, entry)
+		}
+	}
+	switch {
+	case len(resolving) == 0:
+		c.report(compositionExternalMissingOnBase, "Field %s is marked @external in every subgraph that defines it (%s)", coordinate, subgraphNames(entries))
+	case kind == ast.Object && len(resolving) > 1 && len(nonShareable) > 0:
+		c.report(compositionInvalidFieldSharing, "Non-shareable field %s is resolved by multiple subgraphs (%s), but is not @shareable in %s",
+			coordinate, subgraphNames(resolving), subgraphNames(nonShareable))
+	}
+
+	merged := *first.field
+	for _, entry := range entries[1:] {
+		typ, ok := mergeTypes(merged.Type, entry.field.Type, false)
+		if !ok {
+			c.report(compositionFieldTypeMismatch, "Field %s has type %s in %s but %s in %s",
+				coordinate, typeToString(first.field.Type), first.subgraph.name, typeToString(entry.field.Type), entry.subgraph.name)
+			continue
+		}
+		merged.Type = typ
+		if merged.Description == "" {
+			merged.Description = entry.field.Description
+		}
+	}
+
+	var directives []ast.DirectiveList
+	for _, entry := range entries {
+		directives = append(directives, entry.field.Directives)
+	}
+	merged.Directives = c.directives(directives...)
+	merged.Arguments = c.mergeArguments(coordinate, entries)
+	return &merged
+}
+
+// mergeInputFields returns the input fields defined by every subgraph that
+// defines the input type. A required field missing from some subgraphs is an
+// error, since those subgraphs would not accept it.
+func (c *composer) mergeInputFields(entries []typeInSubgraph) ast.FieldList {
+	var merged ast.FieldList
+	for _, field := range entries[0].def.Fields {
+		result := *field
+		result.Directives = c.directives(field.Directives)
+		included := true
+
+		for _, entry := range entries[1:] {
+			other := entry.def.Fields.ForName(field.Name)
+			if other == nil {
+				included = false
+				continue
+			}
+			typ, ok := mergeTypes(result.Type, other.Type, true)
+			if !ok {
+				c.report(compositionFieldTypeMismatch, "Input field %s.%s has type %s in %s but %s in %s",
+					entries[0].def.Name, field.Name, typeToString(field.Type), entries[0].subgraph.name, typeToString(other.Type), entry.subgraph.name)
+				continue
+			}
+			result.Type = typ
+		}
+
+		if included {
+			merged = append(merged, &result)
+		}
+	}
+
+	// Required fields must be defined by every subgraph
+	for _, entry := range entries {
+		for _, field := range entry.def.Fields {
+			if !field.Type.NonNull || field.DefaultValue != nil || merged.ForName(field.Name) != nil {
+				continue
+			}
+			var missing []string
+			for _, other := range entries {
+				if other.def.Fields.ForName(field.Name) == nil {
+					missing = append(missing, other.subgraph.name)
+				}
+			}
+			c.report(compositionRequiredInputFieldMissing, "Input field %s.%s is required in %s but missing in %s",
+				entry.def.Name, field.Name, entry.subgraph.name, strings.Join(missing, ", "))
+			merged = append(merged, field)
+		}
+	}
+
+	return merged
+}
+
+// mergeType merges a type across the subgraphs that define it.
+func (c *composer) mergeType(entries []typeInSubgraph) *ast.Definition {
+	first := entries[0]
+	merged := &ast.Definition{
+		Kind:        first.def.Kind,
+		Name:        first.def.Name,
+		Description: first.def.Description,
+		Position:    first.def.Position,
+	}
+
+	var directives []ast.DirectiveList
+	for _, entry := range entries {
+		if entry.def.Kind != first.def.Kind {
+			c.report(compositionTypeKindMismatch, "Type %s is a %s in %s but a %s in %s",
+				first.def.Name, kindToString(string(first.def.Kind)), first.subgraph.name, kindToString(string(entry.def.Kind)), entry.subgraph.name)
+			return nil
+		}
+		if merged.Description == "" {
+			merged.Description = entry.def.Description
+		}
+		directives = append(directives, entry.def.Directives)
+		for _, iface := range entry.def.Interfaces {
+			if !slices.Contains(merged.Interfaces, iface) {
+				merged.Interfaces = append(merged.Interfaces, iface)
+			}
+		}
+		for _, member := range entry.def.Types {
+			if !slices.Contains(merged.Types, member) {
+				merged.Types = append(merged.Types, member)
+			}
+		}
+		for _, value := range entry.def.EnumValues {
+			if merged.EnumValues.ForName(value.Name) == nil {
+				merged.EnumValues = append(merged.EnumValues, value)
+			}
+		}
+	}
+	merged.Directives = c.directives(directives...)
+
+	switch first.def.Kind {
+	case ast.Object, ast.Interface:
+		var names []string
+		fields := map[string][]fieldInSubgraph{}
+		for _, entry := range entries {
+			for _, field := range userFields(entry.def) {
+				if entry.def == entry.subgraph.schema.Query && (field.Name == "_entities" || field.Name == "_service") {
+					continue
+				}
+				if _, seen := fields[field.Name]; !seen {
+					names = append(names, field.Name)
+				}
+				fields[field.Name] = append(fields[field.Name], fieldInSubgraph{entry, field})
+			}
+		}
+		for _, name := range names {
+			merged.Fields = append(merged.Fields, c.mergeField(first.def.Kind, fields[name]))
+		}
+		if len(merged.Fields) == 0 {
+			// Only federation fields, e.g. a Query with just _service
+			return nil
+		}
+	case ast.InputObject:
+		merged.Fields = c.mergeInputFields(entries)
+	}
+
+	return merged
+}
+
+// checkKeys checks that every @key selects fields of its entity.
+func (c *composer) checkKeys() {
+	for _, sg := range c.subgraphs {
+		for _, def := range userTypes(sg.schema) {
+			for _, directive := range def.Directives {
+				if !isKeyDirective(directive) {
+					continue
+				}
+				fields := directive.Arguments.ForName("fields")
+				if fields == nil {
+					continue
+				}
+				if problem := checkKeyFields(sg.schema, def, fields.Value.Raw); problem != "" {
+					c.report(compositionKeyInvalidFields, "@key(fields: %q) on %s in %s is invalid: %s", fields.Value.Raw, def.Name, sg.name, problem)
+				}
+			}
+		}
+	}
+}
+
+// compose merges the subgraphs into a supergraph schema document.
+func (c *composer) compose() *ast.SchemaDocument {
+	c.federationTypes, c.federationDirectives = federationNames()
+
+	types := map[string][]typeInSubgraph{}
+	for _, sg := range c.subgraphs {
+		for _, def := range userTypes(sg.schema) {
+			if !c.federationTypes[def.Name] {
+				types[def.Name] = append(types[def.Name], typeInSubgraph{sg, def})
+			}
+		}
+	}
+
+	doc := &ast.SchemaDocument{}
+	for _, name := range slices.Sorted(maps.Keys(types)) {
+		if def := c.mergeType(types[name]); def != nil {
+			doc.Definitions = append(doc.Definitions, def)
+		}
+	}
+
+	for _, sg := range c.subgraphs {
+		for _, directive := range sortDirectiveDefinitions(sg.schema.Directives, printSortAlpha) {
+			if directive.Position != nil && directive.Position.Src != nil && directive.Position.Src.BuiltIn {
+				continue
+			}
+			if !c.federationDirectives[directive.Name] && doc.Directives.ForName(directive.Name) == nil {
+				doc.Directives = append(doc.Directives, directive)
+			}
+		}
+	}
+
+	c.checkKeys()
+	return doc
+}
+
+// composeSubgraphs composes subgraph files into a supergraph schema. Every
+// composition problem is returned as compositionErrors.
+func composeSubgraphs(paths []string) (*ast.Schema, error) {
+	c := &composer{}
+	for _, path := range paths {
+		sg, err := loadSubgraph(path)
+		if err != nil {
+			return nil, err
+		}
+		c.subgraphs = append(c.subgraphs, sg)
+	}
+
+	doc := c.compose()
+	if len(c.errors) > 0 {
+		return nil, compositionErrors(c.errors)
+	}
+
+	prelude, err := parser.ParseSchema(validator.Prelude)
+	if err != nil {
+		return nil, err
+	}
+	prelude.Merge(doc)
+
+	schema, err := validator.ValidateSchemaDocument(prelude)
+	if err != nil {
+		return nil, compositionErrors{{Code: compositionInvalidSupergraph, Message: err.Error()}}
+	}
+	return schema, nil
+}
+
+func NewComposeCmd() *cobra.Command {
+	opts := &composeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "compose <subgraph>...",
+		Short: "Composes Apollo Federation subgraphs into a supergraph",
+		Long: `Composes Apollo Federation subgraph schemas into the supergraph clients see,
+and prints it as SDL.
+
+Each subgraph is loaded in federation mode (see --federation) and named after
+its file. Types with the same name are merged: entities are joined across
+subgraphs by their @key, fields and enum values are combined, and input types
+keep the fields every subgraph defines. Federation directives and types are
+left out of the result.
+
+Composition errors:
+  TYPE_KIND_MISMATCH             a type has a different kind in two subgraphs
+  FIELD_TYPE_MISMATCH            a field has incompatible types in two
+                                 subgraphs (differing nullability is merged)
+  FIELD_ARGUMENT_TYPE_MISMATCH   an argument has different types
+  INVALID_FIELD_SHARING          a field is resolved by several subgraphs but
+                                 is not @shareable in all of them (v2 only)
+  EXTERNAL_MISSING_ON_BASE       a field is @external in every subgraph
+  KEY_INVALID_FIELDS             a @key selects a field the entity lacks
+  REQUIRED_INPUT_FIELD_MISSING_IN_SOME_SUBGRAPH
+                                 a required input field is not defined by
+                                 every subgraph that defines the input type
+  INVALID_SUPERGRAPH             the merged schema is not valid GraphQL
+
+The errors are printed in the output format and the exit status is 1.
+
+Every other command can run against the supergraph directly by passing the
+subgraphs with --subgraph instead of --schema.`,
+		Example: `  # Print the supergraph
+  gqlx compose accounts.graphql products.graphql reviews.graphql
+
+  # Save the supergraph to a file
+  gqlx compose subgraphs/*.graphql -o supergraph.graphql
+
+  # Explore the supergraph without saving it
+  gqlx paths Review --subgraph accounts.graphql --subgraph reviews.graphql`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCompose(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "", "Write the supergraph to a file instead of stdout")
+
+	return cmd
+}
+
+func runCompose(cmd *cobra.Command, args []string, opts *composeOptions) error {
+	schema, err := composeSubgraphs(args)
+
+	var errs compositionErrors
+	if errors.As(err, &errs) {
+		renderer := render.Renderer[CompositionError]{
+			Data:         errs,
+			TextFormat:   formatCompositionErrorText,
+			PrettyFormat: formatCompositionErrorsPretty,
+		}
+		output, err := renderer.Render(outputFormat)
+		if err != nil {
+			return fmt.Errorf("error rendering output: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), output)
+
+		// The errors are the error message
+		cmd.SilenceErrors = true
+		return ErrCompositionFailed
+	}
+	if err != nil {
+		return err
+	}
+
+	defs := slices.Collect(maps.Values(schema.Types))
+	sortDefinitions(defs, printSortAlpha)
+	sdl := printSchema(schema, defs, &printOptions{sort: printSortAlpha, indent: 2})
+
+	if opts.output != "" {
+		if err := os.WriteFile(opts.output, []byte(sdl), 0644); err != nil {
+			return fmt.Errorf("failed to write supergraph: %w", err)
+		}
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), sdl)
+	return nil
+}
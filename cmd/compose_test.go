@@ -0,0 +1,255 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type compositionError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// setupSubgraphs writes each subgraph to <name>.graphql in a temp directory
+// and returns the paths in the order given.
+func setupSubgraphs(t *testing.T, subgraphs ...[2]string) []string {
+	t.Helper()
+
+	dir := t.TempDir()
+	var paths []string
+	for _, sg := range subgraphs {
+		path := filepath.Join(dir, sg[0]+".graphql")
+		require.NoError(t, os.WriteFile(path, []byte(sg[1]), 0644))
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+const accountsSubgraph = `
+extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@shareable"])
+
+type Query {
+	me: User
+}
+
+type User @key(fields: "id") {
+	id: ID!
+	name: String
+	createdAt: Date @shareable
+}
+
+scalar Date
+
+enum Role {
+	ADMIN
+}
+`
+
+const reviewsSubgraph = `
+extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@shareable", "@external"])
+
+type Query {
+	reviews(first: Int): [Review!]!
+}
+
+type Review {
+	body: String!
+	author: User
+}
+
+type User @key(fields: "id") {
+	id: ID!
+	createdAt: Date! @shareable
+	reviews: [Review]
+}
+
+scalar Date
+
+enum Role {
+	EDITOR
+}
+`
+
+func TestCompose_MergesEntitiesAndSharedTypes(t *testing.T) {
+	paths := setupSubgraphs(t, [2]string{"accounts", accountsSubgraph}, [2]string{"reviews", reviewsSubgraph})
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"compose"}, paths...))
+	require.NoError(t, err)
+
+	assert.Equal(t, `scalar Date
+
+type Query {
+  me: User
+  reviews(first: Int): [Review!]!
+}
+
+type Review {
+  body: String!
+  author: User
+}
+
+enum Role {
+  ADMIN
+  EDITOR
+}
+
+type User {
+  id: ID!
+  name: String
+  createdAt: Date
+  reviews: [Review]
+}
+`, stdout)
+}
+
+func TestCompose_WritesOutputFile(t *testing.T) {
+	paths := setupSubgraphs(t, [2]string{"accounts", accountsSubgraph}, [2]string{"reviews", reviewsSubgraph})
+	output := filepath.Join(t.TempDir(), "supergraph.graphql")
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"compose", "-o", output}, paths...))
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	// The supergraph loads as a regular schema
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"fields", "User", "-s", output, "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "reviews: [Review]")
+}
+
+func TestCompose_SubgraphFlagLoadsSupergraph(t *testing.T) {
+	paths := setupSubgraphs(t, [2]string{"accounts", accountsSubgraph}, [2]string{"reviews", reviewsSubgraph})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "Review", "--subgraph", paths[0], "--subgraph", paths[1], "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "Query.reviews(...) -> Review\nQuery.me -> User.reviews -> Review\nQuery.reviews(...) -> Review.author -> User.reviews -> Review\n", stdout)
+}
+
+func TestCompose_ReportsErrors(t *testing.T) {
+	paths := setupSubgraphs(t,
+		[2]string{"accounts", accountsSubgraph},
+		[2]string{"profiles", `
+			extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@external"])
+
+			type Query {
+				profile: User
+			}
+
+			type User @key(fields: "uid") {
+				id: String!
+				name: String
+				bio: String @external
+			}
+
+			type Date {
+				value: String
+			}
+		`},
+	)
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"compose", "-f", "json"}, paths...))
+	require.ErrorIs(t, err, cmd.ErrCompositionFailed)
+
+	var errs []compositionError
+	require.NoError(t, json.Unmarshal([]byte(stdout), &errs))
+	assert.Equal(t, []compositionError{
+		{Code: "TYPE_KIND_MISMATCH", Message: "Type Date is a scalar in accounts but a type in profiles"},
+		{Code: "INVALID_FIELD_SHARING", Message: "Non-shareable field User.id is resolved by multiple subgraphs (accounts, profiles), but is not @shareable in profiles"},
+		{Code: "FIELD_TYPE_MISMATCH", Message: "Field User.id has type ID! in accounts but String! in profiles"},
+		{Code: "INVALID_FIELD_SHARING", Message: "Non-shareable field User.name is resolved by multiple subgraphs (accounts, profiles), but is not @shareable in accounts, profiles"},
+		{Code: "EXTERNAL_MISSING_ON_BASE", Message: "Field User.bio is marked @external in every subgraph that defines it (profiles)"},
+		{Code: "KEY_INVALID_FIELDS", Message: "@key(fields: \"uid\") on User in profiles is invalid: User has no field 'uid', did you mean 'id'?"},
+	}, errs)
+}
+
+func TestCompose_V1FieldsAreShareable(t *testing.T) {
+	paths := setupSubgraphs(t,
+		[2]string{"products", federationV1Subgraph},
+		[2]string{"users", `
+			type Query {
+				user(id: ID!): User
+			}
+
+			type User @key(fields: "id") {
+				id: ID!
+				username: String
+			}
+
+			type Review {
+				body: String
+			}
+		`},
+	)
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"compose"}, paths...))
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "type User {\n  id: ID!\n  reviews: [Review]\n  username: String\n}")
+	assert.NotContains(t, stdout, "@key")
+	assert.NotContains(t, stdout, "_entities")
+}
+
+func TestCompose_InputTypesKeepCommonFields(t *testing.T) {
+	paths := setupSubgraphs(t,
+		[2]string{"a", `
+			type Query {
+				search(filter: Filter): [String]
+			}
+
+			input Filter {
+				term: String
+				limit: Int
+			}
+		`},
+		[2]string{"b", `
+			type Query {
+				count(filter: Filter): Int
+			}
+
+			input Filter {
+				term: String!
+				tags: [String]
+			}
+		`},
+	)
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"compose"}, paths...))
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "input Filter {\n  term: String!\n}")
+
+	paths = setupSubgraphs(t,
+		[2]string{"a", `
+			type Query {
+				search(filter: Filter): [String]
+			}
+
+			input Filter {
+				term: String
+			}
+		`},
+		[2]string{"b", `
+			type Query {
+				count(filter: Filter): Int
+			}
+
+			input Filter {
+				term: String
+				owner: ID!
+			}
+		`},
+	)
+
+	stdout, _, err = cmd.ExecuteWithArgs(append([]string{"compose", "-f", "text"}, paths...))
+	require.ErrorIs(t, err, cmd.ErrCompositionFailed)
+	assert.Equal(t, "REQUIRED_INPUT_FIELD_MISSING_IN_SOME_SUBGRAPH: Input field Filter.owner is required in b but missing in a\n", stdout)
+}
+
+func TestCompose_SubgraphDoesNotExist(t *testing.T) {
+	_, _, err := cmd.ExecuteWithArgs([]string{"compose", "missing.graphql"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subgraph file does not exist: missing.graphql")
+}
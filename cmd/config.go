@@ -0,0 +1,378 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// Project Config
+//
+// gqlx reads the standard graphql-config file (graphql.config.yml, .graphqlrc,
+// ...) from the current directory or the closest parent that has one, so the
+// schema doesn't have to be passed to every command:
+//
+//	schema: schema/*.graphql
+//	documents: src/**/*.graphql
+//	extensions:
+//	  gqlx:
+//	    defaults:
+//	      format: json
+//	    commands:
+//	      relay check:
+//	        disable: [connection-arguments]
+//	projects:
+//	  web:
+//	    schema: packages/web/schema.graphql
+//
+// Paths and globs are relative to the config file. Defaults and command
+// defaults are flag values, used when the flag isn't passed explicitly.
+
+// configFileNames are the graphql-config file names, in the order they are
+// looked for in each directory. JSON is read as YAML.
+var configFileNames = []string{
+	"graphql.config.yml",
+	"graphql.config.yaml",
+	"graphql.config.json",
+	".graphqlrc",
+	".graphqlrc.yml",
+	".graphqlrc.yaml",
+	".graphqlrc.json",
+}
+
+// stringList is a config value that is either a single string or a list.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*l = stringList{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var values []string
+		if err := node.Decode(&values); err != nil {
+			return fmt.Errorf("line %d: expected a list of paths", node.Line)
+		}
+		*l = values
+		return nil
+	}
+	return fmt.Errorf("line %d: expected a path or a list of paths", node.Line)
+}
+
+// gqlxExtension is the gqlx section under a project's extensions.
+type gqlxExtension struct {
+	Defaults map[string]any            `yaml:"defaults"`
+	Commands map[string]map[string]any `yaml:"commands"`
+}
+
+type projectConfig struct {
+	Schema     stringList `yaml:"schema"`
+	Documents  stringList `yaml:"documents"`
+	Extensions struct {
+		Gqlx gqlxExtension `yaml:"gqlx"`
+	} `yaml:"extensions"`
+}
+
+type graphqlConfig struct {
+	projectConfig `yaml:",inline"`
+	Projects      map[string]projectConfig `yaml:"projects"`
+}
+
+// project is the config project a command runs in.
+type project struct {
+	name       string
+	configPath string
+	dir        string
+	config     projectConfig
+}
+
+// findConfigFile returns the config file in dir or its closest parent that
+// has one, or "" if there is none.
+func findConfigFile(dir string) string {
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadProject reads the config file found from the current directory and
+// returns the named project. Without a name it returns the "default" project
+// if the config has one, or the top-level settings otherwise. It returns nil
+// if there is no config file.
+func loadProject(name string) (*project, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	path := findConfigFile(cwd)
+	if path == "" {
+		if name != "" {
+			return nil, fmt.Errorf("--project %s requires a GraphQL config file (%s)", name, strings.Join(configFileNames, ", "))
+		}
+		return nil, nil
+	}
+
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config graphqlConfig
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	p := &project{configPath: path, dir: filepath.Dir(path), config: config.projectConfig}
+	switch {
+	case name != "":
+		projectConfig, ok := config.Projects[name]
+		if !ok {
+			names := slices.Sorted(maps.Keys(config.Projects))
+			if len(names) == 0 {
+				return nil, fmt.Errorf("project '%s' does not exist, %s defines no projects", name, path)
+			}
+			if suggestion := findClosest(name, slices.Values(names)); suggestion != "" {
+				return nil, fmt.Errorf("project '%s' does not exist in %s, did you mean '%s'?", name, path, suggestion)
+			}
+			return nil, fmt.Errorf("project '%s' does not exist in %s (projects: %s)", name, path, strings.Join(names, ", "))
+		}
+		p.name, p.config = name, projectConfig
+	case config.Projects["default"].Schema != nil:
+		p.name, p.config = "default", config.Projects["default"]
+	}
+
+	for _, schema := range p.config.Schema {
+		if strings.HasPrefix(schema, "http://") || strings.HasPrefix(schema, "https://") {
+			return nil, fmt.Errorf("%s: remote schema %s is not supported, download it to a file first", path, schema)
+		}
+	}
+
+	return p, nil
+}
+
+// expandBraces expands the {a,b} alternatives in a glob pattern.
+func expandBraces(pattern string) []string {
+	start := strings.Index(pattern, "{")
+	if start == -1 {
+		return []string{pattern}
+	}
+	end := strings.Index(pattern[start:], "}")
+	if end == -1 {
+		return []string{pattern}
+	}
+	end += start
+
+	var patterns []string
+	for _, alternative := range strings.Split(pattern[start+1:end], ",") {
+		patterns = append(patterns, expandBraces(pattern[:start]+alternative+pattern[end+1:])...)
+	}
+	return patterns
+}
+
+// matchGlob matches slash-separated path segments against pattern segments,
+// where a "**" segment matches any number of directories.
+func matchGlob(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchGlob(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	ok, _ := filepath.Match(pattern[0], path[0])
+	return ok && matchGlob(pattern[1:], path[1:])
+}
+
+// globFiles returns the files under dir matching the pattern, sorted. A
+// pattern without glob characters is returned as is, whether it exists or not,
+// so a missing file is reported when it's read.
+func globFiles(dir, pattern string) ([]string, error) {
+	var files []string
+	for _, pattern := range expandBraces(filepath.ToSlash(pattern)) {
+		if !strings.ContainsAny(pattern, "*?[") {
+			files = append(files, filepath.Join(dir, filepath.FromSlash(pattern)))
+			continue
+		}
+
+		// Walk from the directory before the first glob segment
+		segments := strings.Split(pattern, "/")
+		root := dir
+		for len(segments) > 1 && !strings.ContainsAny(segments[0], "*?[") {
+			root = filepath.Join(root, segments[0])
+			segments = segments[1:]
+		}
+
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if entry.IsDir() {
+				if name := entry.Name(); path != root && (name == "node_modules" || name == ".git") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if matchGlob(segments, strings.Split(filepath.ToSlash(rel), "/")) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// displayPath returns path relative to the current directory for messages.
+func displayPath(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(cwd, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// files returns the files matching a project's schema or documents patterns.
+func (p *project) files(patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := globFiles(p.dir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: no files match %s", displayPath(p.configPath), pattern)
+		}
+		for _, match := range matches {
+			files = append(files, displayPath(match))
+		}
+	}
+	return files, nil
+}
+
+// setFlag sets a flag to a config value. Lists set the flag once per item, for
+// flags that can be specified multiple times.
+func setFlag(flag *pflag.Flag, value any) error {
+	values, ok := value.([]any)
+	if !ok {
+		values = []any{value}
+	}
+	for _, v := range values {
+		if err := flag.Value.Set(fmt.Sprint(v)); err != nil {
+			return fmt.Errorf("invalid value %v for %s: %v", v, flag.Name, err)
+		}
+	}
+	flag.Changed = true
+	return nil
+}
+
+// applyDefaults sets the flags a project's config gives defaults for, unless
+// they were passed on the command line. Defaults apply to every command that
+// has the flag; command defaults are keyed by the command path without
+// "gqlx", e.g. "validate" or "relay check", and must name flags it has.
+func (p *project) applyDefaults(cmd *cobra.Command) error {
+	config := p.config.Extensions.Gqlx
+
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	for _, name := range slices.Sorted(maps.Keys(config.Commands[commandPath])) {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			var names []string
+			cmd.Flags().VisitAll(func(flag *pflag.Flag) { names = append(names, flag.Name) })
+			if suggestion := findClosest(name, slices.Values(names)); suggestion != "" {
+				return fmt.Errorf("%s: '%s' has no flag '%s', did you mean '%s'?", displayPath(p.configPath), commandPath, name, suggestion)
+			}
+			return fmt.Errorf("%s: '%s' has no flag '%s'", displayPath(p.configPath), commandPath, name)
+		}
+		if flag.Changed {
+			continue
+		}
+		if err := setFlag(flag, config.Commands[commandPath][name]); err != nil {
+			return fmt.Errorf("%s: %v", displayPath(p.configPath), err)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Defaults)) {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		if err := setFlag(flag, config.Defaults[name]); err != nil {
+			return fmt.Errorf("%s: %v", displayPath(p.configPath), err)
+		}
+	}
+
+	return nil
+}
+
+// schemaPaths returns the schema files to load: --schema if it was passed,
+// the config project's schema if it has one, and schema.graphql otherwise.
+func schemaPaths() []string {
+	if len(configSchemaPaths) > 0 {
+		return configSchemaPaths
+	}
+	return []string{schemaFilePath}
+}
+
+// configure loads the config project for a command and applies it.
+func configure(cmd *cobra.Command) error {
+	activeProject, configSchemaPaths = nil, nil
+
+	p, err := loadProject(projectName)
+	if err != nil || p == nil {
+		return err
+	}
+	if err := p.applyDefaults(cmd); err != nil {
+		return err
+	}
+
+	if !cmd.Flags().Changed("schema") && len(p.config.Schema) > 0 {
+		configSchemaPaths, err = p.files(p.config.Schema)
+		if err != nil {
+			return err
+		}
+	}
+	activeProject = p
+	return nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandBraces(t *testing.T) {
+	assert.Equal(t, []string{"src/*.graphql"}, expandBraces("src/*.graphql"))
+	assert.Equal(t, []string{"src/*.graphql", "src/*.gql"}, expandBraces("src/*.{graphql,gql}"))
+	assert.Equal(t, []string{"a/x.graphql", "a/x.gql", "b/x.graphql", "b/x.gql"}, expandBraces("{a,b}/x.{graphql,gql}"))
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.graphql", "user.graphql", true},
+		{"*.graphql", "src/user.graphql", false},
+		{"**/*.graphql", "user.graphql", true},
+		{"**/*.graphql", "src/a/b/user.graphql", true},
+		{"src/**/queries/*.graphql", "src/app/queries/user.graphql", true},
+		{"src/**/queries/*.graphql", "src/app/user.graphql", false},
+		{"src/*.graphql", "lib/user.graphql", false},
+	}
+
+	for _, tt := range tests {
+		got := matchGlob(strings.Split(tt.pattern, "/"), strings.Split(tt.path, "/"))
+		assert.Equal(t, tt.want, got, "%s matching %s", tt.pattern, tt.path)
+	}
+}
@@ -0,0 +1,232 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConfigDir writes files (relative path -> content) into a temp
+// directory, changes into it and returns it.
+func setupConfigDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	t.Chdir(dir)
+	return dir
+}
+
+const configUserSchema = `
+type Query {
+	user(id: ID!): User
+}
+
+type User {
+	id: ID!
+	name: String
+}
+`
+
+const configPostSchema = `
+extend type Query {
+	posts: [Post]
+}
+
+type Post {
+	title: String
+}
+`
+
+func TestConfig_SchemaFromParentDirectory(t *testing.T) {
+	dir := setupConfigDir(t, map[string]string{
+		"graphql.config.yml":     "schema: schema/*.graphql\n",
+		"schema/user.graphql":    configUserSchema,
+		"schema/post.graphql":    configPostSchema,
+		"packages/web/README.md": "",
+	})
+	t.Chdir(filepath.Join(dir, "packages", "web"))
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "user(id: ID!): User")
+	assert.Contains(t, stdout, "posts: [Post]")
+}
+
+func TestConfig_SchemaFlagOverridesConfig(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		".graphqlrc":     "schema: missing.graphql\n",
+		"schema.graphql": configUserSchema,
+	})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"types", "-f", "text"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schema file does not exist: missing.graphql (from .graphqlrc)")
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"types", "-s", "schema.graphql", "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "User")
+}
+
+func TestConfig_FallsBackToSchemaGraphql(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		".graphqlrc.yml": "extensions:\n  gqlx:\n    defaults:\n      format: json\n",
+		"schema.graphql": configUserSchema,
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"types", "--type"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `"name": "User"`)
+}
+
+func TestConfig_Projects(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": `
+projects:
+  default:
+    schema: user.graphql
+  posts:
+    schema: [user.graphql, post.graphql]
+`,
+		"user.graphql": configUserSchema,
+		"post.graphql": configPostSchema,
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-f", "text"})
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "posts")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"fields", "Query", "--project", "posts", "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "posts: [Post]")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"types", "--project", "post"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "project 'post' does not exist in")
+	assert.Contains(t, err.Error(), "did you mean 'posts'?")
+}
+
+func TestConfig_ProjectWithoutConfigFile(t *testing.T) {
+	setupConfigDir(t, map[string]string{"schema.graphql": configUserSchema})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"types", "--project", "web"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--project web requires a GraphQL config file")
+}
+
+func TestConfig_CommandDefaults(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": `
+schema: schema.graphql
+extensions:
+  gqlx:
+    defaults:
+      format: text
+    commands:
+      fields:
+        format: json
+      relay check:
+        disable: [node-interface, node-field, nodes-field, page-info]
+`,
+		"schema.graphql": configUserSchema,
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "User"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, `"name": "id"`)
+
+	// Flags passed explicitly win over the config
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"fields", "User", "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "id: ID!")
+
+	stdout, stderr, err := cmd.ExecuteWithArgs([]string{"relay", "check"})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "✓ Schema follows the Relay conventions")
+}
+
+func TestConfig_UnknownCommandFlag(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": "extensions:\n  gqlx:\n    commands:\n      relay check:\n        disabel: [page-info]\n",
+		"schema.graphql":     configUserSchema,
+	})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"relay", "check"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "graphql.config.yml: 'relay check' has no flag 'disabel', did you mean 'disable'?")
+}
+
+func TestConfig_ValidateDocuments(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml":         "schema: schema.graphql\ndocuments: 'src/**/*.{graphql,gql}'\n",
+		"schema.graphql":             configUserSchema,
+		"src/user.graphql":           "query User { user(id: 1) { id name } }",
+		"src/nested/broken.gql":      "query Broken { user(id: 1) { nmae } }",
+		"src/node_modules/x.graphql": "query Ignored { nothing }",
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"validate", "--documents", "-f", "json"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+
+	var results []struct {
+		File  string `json:"file"`
+		Valid bool   `json:"valid"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &results))
+	assert.Equal(t, []struct {
+		File  string `json:"file"`
+		Valid bool   `json:"valid"`
+	}{
+		{File: filepath.Join("src", "nested", "broken.gql"), Valid: false},
+		{File: filepath.Join("src", "user.graphql"), Valid: true},
+	}, results)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"validate", "--documents", "-f", "text"})
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+	assert.Contains(t, stdout, "src/nested/broken.gql:1:30")
+	assert.NotContains(t, stdout, "src/user.graphql")
+	assert.Contains(t, stdout, "✗ 1 of 2 documents have errors")
+
+	// A file argument or stdin still validates just that query
+	_, _, err = cmd.ExecuteWithArgs([]string{"validate", "src/user.graphql"})
+	require.NoError(t, err)
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"validate", "--documents", "src/user.graphql"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--documents can't be used with a file")
+}
+
+func TestConfig_ValidatePipedQueryWithDocuments(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": "schema: schema.graphql\ndocuments: 'src/**/*.graphql'\n",
+		"schema.graphql":     configUserSchema,
+		"src/user.graphql":   "query User { user(id: 1) { id } }",
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgsAndStdin([]string{"validate", "-f", "text"}, bytes.NewBufferString("{ bad }"))
+	require.ErrorIs(t, err, cmd.ErrValidationFailed)
+	assert.Contains(t, stdout, "✗ Query has 1 error:")
+	assert.Contains(t, stdout, "stdin:1:3")
+	assert.NotContains(t, stdout, "documents")
+}
+
+func TestConfig_ValidateDocumentsWithoutConfig(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"schema.graphql": configUserSchema,
+	})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"validate", "--documents"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--documents needs a GraphQL config file that defines documents")
+}
@@ -0,0 +1,397 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type convertOptions struct {
+	to       string
+	typeName string
+}
+
+const (
+	convertToIntrospection = "introspection"
+	convertToJSONSchema    = "jsonschema"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// optionalString returns nil for an empty string, so that it marshals to null.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// sortedTypeNames returns the names of the schema's types in alphabetical order.
+func sortedTypeNames(schema *ast.Schema) []string {
+	return slices.Sorted(maps.Keys(schema.Types))
+}
+
+func introspectTypeRef(schema *ast.Schema, t *ast.Type) IntrospectionTypeRef {
+	if t.NonNull {
+		inner := *t
+		inner.NonNull = false
+		ofType := introspectTypeRef(schema, &inner)
+		return IntrospectionTypeRef{Kind: "NON_NULL", OfType: &ofType}
+	}
+	if t.Elem != nil {
+		ofType := introspectTypeRef(schema, t.Elem)
+		return IntrospectionTypeRef{Kind: "LIST", OfType: &ofType}
+	}
+	name := t.NamedType
+	kind := ""
+	if def := schema.Types[name]; def != nil {
+		kind = string(def.Kind)
+	}
+	return IntrospectionTypeRef{Kind: kind, Name: &name}
+}
+
+func introspectNamedRefs(schema *ast.Schema, names []string) []IntrospectionTypeRef {
+	refs := []IntrospectionTypeRef{}
+	for _, name := range names {
+		refs = append(refs, introspectTypeRef(schema, ast.NamedType(name, nil)))
+	}
+	return refs
+}
+
+func introspectInputValue(schema *ast.Schema, name, description string, t *ast.Type, defaultValue *ast.Value, directives ast.DirectiveList) IntrospectionInput {
+	input := IntrospectionInput{
+		Name:        name,
+		Description: optionalString(description),
+		Type:        introspectTypeRef(schema, t),
+	}
+	if defaultValue != nil {
+		input.DefaultValue = optionalString(defaultValue.String())
+	}
+	if reason, ok := deprecationReason(directives); ok {
+		input.IsDeprecated = true
+		input.DeprecationReason = &reason
+	}
+	return input
+}
+
+func introspectArgs(schema *ast.Schema, args ast.ArgumentDefinitionList) []IntrospectionInput {
+	result := []IntrospectionInput{}
+	for _, arg := range args {
+		result = append(result, introspectInputValue(schema, arg.Name, arg.Description, arg.Type, arg.DefaultValue, arg.Directives))
+	}
+	return result
+}
+
+func introspectType(schema *ast.Schema, def *ast.Definition) IntrospectionType {
+	t := IntrospectionType{
+		Kind:        string(def.Kind),
+		Name:        def.Name,
+		Description: optionalString(def.Description),
+	}
+
+	switch def.Kind {
+	case ast.Scalar:
+		if specifiedBy := def.Directives.ForName("specifiedBy"); specifiedBy != nil {
+			if url := specifiedBy.Arguments.ForName("url"); url != nil && url.Value != nil {
+				t.SpecifiedByURL = &url.Value.Raw
+			}
+		}
+	case ast.Object, ast.Interface:
+		t.Fields = []IntrospectionField{}
+		for _, field := range def.Fields {
+			// Meta fields such as __schema and __type are not part of introspection results
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			f := IntrospectionField{
+				Name:        field.Name,
+				Description: optionalString(field.Description),
+				Args:        introspectArgs(schema, field.Arguments),
+				Type:        introspectTypeRef(schema, field.Type),
+			}
+			if reason, ok := deprecationReason(field.Directives); ok {
+				f.IsDeprecated = true
+				f.DeprecationReason = &reason
+			}
+			t.Fields = append(t.Fields, f)
+		}
+		t.Interfaces = introspectNamedRefs(schema, def.Interfaces)
+		if def.Kind == ast.Interface {
+			var possible []string
+			for _, p := range schema.GetPossibleTypes(def) {
+				if p.Kind == ast.Object {
+					possible = append(possible, p.Name)
+				}
+			}
+			slices.Sort(possible)
+			t.PossibleTypes = introspectNamedRefs(schema, possible)
+		}
+	case ast.Union:
+		t.PossibleTypes = introspectNamedRefs(schema, def.Types)
+	case ast.Enum:
+		t.EnumValues = []IntrospectionEnumValue{}
+		for _, value := range def.EnumValues {
+			v := IntrospectionEnumValue{
+				Name:        value.Name,
+				Description: optionalString(value.Description),
+			}
+			if reason, ok := deprecationReason(value.Directives); ok {
+				v.IsDeprecated = true
+				v.DeprecationReason = &reason
+			}
+			t.EnumValues = append(t.EnumValues, v)
+		}
+	case ast.InputObject:
+		t.InputFields = []IntrospectionInput{}
+		for _, field := range def.Fields {
+			t.InputFields = append(t.InputFields, introspectInputValue(schema, field.Name, field.Description, field.Type, field.DefaultValue, field.Directives))
+		}
+	}
+
+	return t
+}
+
+// buildIntrospection converts a schema into the result of the standard
+// introspection query. Types and directives are sorted by name.
+func buildIntrospection(schema *ast.Schema) IntrospectionResult {
+	result := IntrospectionSchema{
+		Description: optionalString(schema.Description),
+		Types:       []IntrospectionType{},
+		Directives:  []IntrospectionDirective{},
+	}
+	if schema.Query != nil {
+		result.QueryType = &IntrospectionNamedRef{Name: schema.Query.Name}
+	}
+	if schema.Mutation != nil {
+		result.MutationType = &IntrospectionNamedRef{Name: schema.Mutation.Name}
+	}
+	if schema.Subscription != nil {
+		result.SubscriptionType = &IntrospectionNamedRef{Name: schema.Subscription.Name}
+	}
+
+	for _, name := range sortedTypeNames(schema) {
+		result.Types = append(result.Types, introspectType(schema, schema.Types[name]))
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Directives)) {
+		directive := schema.Directives[name]
+		d := IntrospectionDirective{
+			Name:         directive.Name,
+			Description:  optionalString(directive.Description),
+			IsRepeatable: directive.IsRepeatable,
+			Locations:    []string{},
+			Args:         introspectArgs(schema, directive.Arguments),
+		}
+		for _, loc := range directive.Locations {
+			d.Locations = append(d.Locations, string(loc))
+		}
+		result.Directives = append(result.Directives, d)
+	}
+
+	return IntrospectionResult{Schema: result}
+}
+
+// builtinScalarJSONTypes maps the built-in GraphQL scalars to JSON Schema types.
+var builtinScalarJSONTypes = map[string]string{
+	"Int":     "integer",
+	"Float":   "number",
+	"String":  "string",
+	"Boolean": "boolean",
+	"ID":      "string",
+}
+
+// jsonSchemaConverter builds JSON Schema definitions for input types, collecting
+// every named type it references into defs.
+type jsonSchemaConverter struct {
+	schema *ast.Schema
+	defs   map[string]*JSONSchema
+}
+
+func jsonSchemaRef(name string) *JSONSchema {
+	return &JSONSchema{Ref: "#/$defs/" + name}
+}
+
+// typeSchema returns the subschema for a (possibly wrapped) GraphQL input type.
+// Nullable types also accept null.
+func (c *jsonSchemaConverter) typeSchema(t *ast.Type) *JSONSchema {
+	var s *JSONSchema
+	if t.Elem != nil {
+		s = &JSONSchema{Type: "array", Items: c.typeSchema(t.Elem)}
+	} else if jsonType, ok := builtinScalarJSONTypes[t.NamedType]; ok {
+		s = &JSONSchema{Type: jsonType}
+	} else {
+		c.addDefinition(t.NamedType)
+		s = jsonSchemaRef(t.NamedType)
+	}
+
+	if t.NonNull {
+		return s
+	}
+	if jsonType, ok := s.Type.(string); ok {
+		s.Type = []string{jsonType, "null"}
+		return s
+	}
+	return &JSONSchema{AnyOf: []*JSONSchema{s, {Type: "null"}}}
+}
+
+// addDefinition adds the named type, and every type it references, to defs.
+func (c *jsonSchemaConverter) addDefinition(name string) {
+	if _, ok := c.defs[name]; ok {
+		return
+	}
+	def := c.schema.Types[name]
+	if def == nil {
+		return
+	}
+
+	s := &JSONSchema{Title: def.Name, Description: def.Description}
+	// Register before recursing so that recursive input types terminate
+	c.defs[name] = s
+
+	switch def.Kind {
+	case ast.Enum:
+		s.Type = "string"
+		for _, value := range def.EnumValues {
+			s.Enum = append(s.Enum, value.Name)
+		}
+	case ast.InputObject:
+		noAdditional := false
+		s.Type = "object"
+		s.AdditionalProperties = &noAdditional
+		s.Properties = map[string]*JSONSchema{}
+		for _, field := range def.Fields {
+			prop := c.typeSchema(field.Type)
+			// Annotations may sit next to $ref in 2020-12, so every
+			// property can carry its own description and default
+			prop.Description = field.Description
+			if field.DefaultValue != nil {
+				if value, err := field.DefaultValue.Value(nil); err == nil {
+					prop.Default = value
+				}
+			}
+			if _, ok := deprecationReason(field.Directives); ok {
+				prop.Deprecated = true
+			}
+			s.Properties[field.Name] = prop
+
+			if field.Type.NonNull && field.DefaultValue == nil {
+				s.Required = append(s.Required, field.Name)
+			}
+		}
+	}
+	// Custom scalars accept any JSON value, so they only carry annotations
+}
+
+// buildJSONSchema returns a JSON Schema document for the given input object or
+// enum, or for every input object and enum in the schema when typeName is empty.
+func buildJSONSchema(schema *ast.Schema, typeName string) *JSONSchema {
+	c := &jsonSchemaConverter{schema: schema, defs: map[string]*JSONSchema{}}
+	doc := &JSONSchema{Schema: jsonSchemaDialect}
+
+	if typeName != "" {
+		c.addDefinition(typeName)
+		doc.Ref = "#/$defs/" + typeName
+	} else {
+		for _, name := range sortedTypeNames(schema) {
+			def := schema.Types[name]
+			if !def.BuiltIn && (def.Kind == ast.InputObject || def.Kind == ast.Enum) {
+				c.addDefinition(name)
+			}
+		}
+	}
+
+	doc.Defs = c.defs
+	return doc
+}
+
+func NewConvertCmd() *cobra.Command {
+	opts := &convertOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Converts the schema to introspection JSON or JSON Schema",
+		Long: `Converts the loaded schema into other schema formats.
+
+Targets:
+  introspection  The result of the standard introspection query ({"__schema": ...}),
+                 as consumed by mock servers and client code generators
+  jsonschema     A JSON Schema (draft 2020-12) document with a definition under
+                 $defs for every input object and enum
+
+For jsonschema, --type selects a single input object or enum. The document then
+references that type at its root and only contains the types it depends on.
+
+Non-null input fields without a default value are required. Nullable types also
+accept null, lists become arrays, and descriptions, defaults and deprecation are
+carried over. Custom scalars accept any value.
+
+The --format flag is ignored; output is always JSON.`,
+		Example: `  # Write an introspection result for a mock server
+  gqlx convert --to introspection > introspection.json
+
+  # JSON Schema for every input object and enum
+  gqlx convert --to jsonschema
+
+  # JSON Schema for a single input type, for a form builder
+  gqlx convert --to jsonschema --type CreateUserInput`,
+		Args: cobra.NoArgs,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConvert(cmd, args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.to, "to", "", "Target format: introspection or jsonschema (required)")
+	cmd.Flags().StringVar(&opts.typeName, "type", "", "Input object or enum to convert (jsonschema only)")
+	_ = cmd.MarkFlagRequired("to")
+	_ = cmd.RegisterFlagCompletionFunc("to", cobra.FixedCompletions([]string{convertToIntrospection, convertToJSONSchema}, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func runConvert(cmd *cobra.Command, args []string, opts *convertOptions) error {
+	if opts.to != convertToIntrospection && opts.to != convertToJSONSchema {
+		return fmt.Errorf("--to must be '%s' or '%s', got '%s'", convertToIntrospection, convertToJSONSchema, opts.to)
+	}
+	if opts.typeName != "" && opts.to != convertToJSONSchema {
+		return fmt.Errorf("--type can only be used with --to %s", convertToJSONSchema)
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	var result any
+	switch opts.to {
+	case convertToIntrospection:
+		result = buildIntrospection(schema)
+	case convertToJSONSchema:
+		if opts.typeName != "" {
+			if err := validateTypeExists(schema, opts.typeName, "type"); err != nil {
+				return err
+			}
+			if kind := schema.Types[opts.typeName].Kind; kind != ast.InputObject && kind != ast.Enum {
+				return fmt.Errorf("'%s' is not an input or enum (it's a %s)", opts.typeName, kindToString(string(kind)))
+			}
+		}
+		result = buildJSONSchema(schema, opts.typeName)
+	}
+
+	bytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+	return nil
+}
@@ -0,0 +1,337 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const convertTestSchema = `
+"A node"
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String @deprecated(reason: "Use fullName")
+	fullName: String!
+	posts(first: Int = 10, after: String): [Post!]!
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+}
+
+union SearchResult = User | Post
+
+"User roles"
+enum Role {
+	ADMIN
+	"Regular member"
+	MEMBER
+	GUEST @deprecated
+}
+
+scalar DateTime @specifiedBy(url: "https://example.com/datetime")
+
+"Input for creating a user"
+input CreateUserInput {
+	"Display name"
+	name: String!
+	role: Role = MEMBER
+	tags: [String!]
+	scores: [Float]!
+	birthday: DateTime
+	address: AddressInput
+	age: Int! = 18
+}
+
+input AddressInput {
+	street: String!
+	parent: AddressInput
+}
+
+type Query {
+	node(id: ID!): Node
+	search(term: String!): [SearchResult!]!
+}
+
+type Mutation {
+	createUser(input: CreateUserInput!): User!
+}
+`
+
+func setupConvertTestSchema(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	err := os.WriteFile(schemaPath, []byte(convertTestSchema), 0644)
+	require.NoError(t, err)
+	return schemaPath
+}
+
+func introspectionTypes(t *testing.T, stdout string) map[string]cmd.IntrospectionType {
+	t.Helper()
+	var result cmd.IntrospectionResult
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	types := map[string]cmd.IntrospectionType{}
+	for _, typ := range result.Schema.Types {
+		types[typ.Name] = typ
+	}
+	return types
+}
+
+func TestConvert_Introspection_RootTypes(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "introspection", "-s", schemaPath})
+	require.NoError(t, err)
+
+	var raw map[string]map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &raw))
+	schema := raw["__schema"]
+	require.NotNil(t, schema)
+	assert.Equal(t, map[string]any{"name": "Query"}, schema["queryType"])
+	assert.Equal(t, map[string]any{"name": "Mutation"}, schema["mutationType"])
+	assert.Nil(t, schema["subscriptionType"])
+}
+
+func TestConvert_Introspection_Types(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "introspection", "-s", schemaPath})
+	require.NoError(t, err)
+	types := introspectionTypes(t, stdout)
+
+	// Built-in scalars and introspection types are part of the result
+	assert.Equal(t, "SCALAR", types["String"].Kind)
+	assert.Equal(t, "OBJECT", types["__Type"].Kind)
+
+	// Meta fields are not listed on Query
+	for _, field := range types["Query"].Fields {
+		assert.NotEqual(t, "__schema", field.Name)
+		assert.NotEqual(t, "__type", field.Name)
+	}
+
+	node := types["Node"]
+	assert.Equal(t, "INTERFACE", node.Kind)
+	require.NotNil(t, node.Description)
+	assert.Equal(t, "A node", *node.Description)
+	var possible []string
+	for _, ref := range node.PossibleTypes {
+		possible = append(possible, *ref.Name)
+	}
+	assert.Equal(t, []string{"Post", "User"}, possible)
+
+	union := types["SearchResult"]
+	assert.Len(t, union.PossibleTypes, 2)
+	assert.Nil(t, union.Fields)
+
+	scalar := types["DateTime"]
+	require.NotNil(t, scalar.SpecifiedByURL)
+	assert.Equal(t, "https://example.com/datetime", *scalar.SpecifiedByURL)
+}
+
+func TestConvert_Introspection_TypeRefs(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "introspection", "-s", schemaPath})
+	require.NoError(t, err)
+	types := introspectionTypes(t, stdout)
+
+	var posts cmd.IntrospectionField
+	for _, field := range types["User"].Fields {
+		if field.Name == "posts" {
+			posts = field
+		}
+	}
+
+	// [Post!]! is NON_NULL -> LIST -> NON_NULL -> OBJECT Post
+	ref := posts.Type
+	assert.Equal(t, "NON_NULL", ref.Kind)
+	assert.Nil(t, ref.Name)
+	require.NotNil(t, ref.OfType)
+	assert.Equal(t, "LIST", ref.OfType.Kind)
+	require.NotNil(t, ref.OfType.OfType)
+	assert.Equal(t, "NON_NULL", ref.OfType.OfType.Kind)
+	require.NotNil(t, ref.OfType.OfType.OfType)
+	assert.Equal(t, "OBJECT", ref.OfType.OfType.OfType.Kind)
+	assert.Equal(t, "Post", *ref.OfType.OfType.OfType.Name)
+
+	require.Len(t, posts.Args, 2)
+	assert.Equal(t, "first", posts.Args[0].Name)
+	require.NotNil(t, posts.Args[0].DefaultValue)
+	assert.Equal(t, "10", *posts.Args[0].DefaultValue)
+	assert.Nil(t, posts.Args[1].DefaultValue)
+}
+
+func TestConvert_Introspection_Deprecation(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "introspection", "-s", schemaPath})
+	require.NoError(t, err)
+	types := introspectionTypes(t, stdout)
+
+	for _, field := range types["User"].Fields {
+		if field.Name == "name" {
+			assert.True(t, field.IsDeprecated)
+			require.NotNil(t, field.DeprecationReason)
+			assert.Equal(t, "Use fullName", *field.DeprecationReason)
+		}
+		if field.Name == "fullName" {
+			assert.False(t, field.IsDeprecated)
+			assert.Nil(t, field.DeprecationReason)
+		}
+	}
+
+	for _, value := range types["Role"].EnumValues {
+		if value.Name == "GUEST" {
+			assert.True(t, value.IsDeprecated)
+			require.NotNil(t, value.DeprecationReason)
+			assert.Equal(t, "No longer supported", *value.DeprecationReason)
+		}
+	}
+}
+
+func TestConvert_Introspection_InputFields(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "introspection", "-s", schemaPath})
+	require.NoError(t, err)
+	types := introspectionTypes(t, stdout)
+
+	input := types["CreateUserInput"]
+	assert.Equal(t, "INPUT_OBJECT", input.Kind)
+	assert.Nil(t, input.Fields)
+	require.NotEmpty(t, input.InputFields)
+	assert.Equal(t, "name", input.InputFields[0].Name)
+	assert.Equal(t, "MEMBER", *input.InputFields[1].DefaultValue)
+}
+
+func jsonSchemaDefs(t *testing.T, stdout string) map[string]map[string]any {
+	t.Helper()
+	var doc struct {
+		Schema string                    `json:"$schema"`
+		Ref    string                    `json:"$ref"`
+		Defs   map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", doc.Schema)
+	return doc.Defs
+}
+
+func TestConvert_JSONSchema_AllInputsAndEnums(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "jsonschema", "-s", schemaPath})
+	require.NoError(t, err)
+	defs := jsonSchemaDefs(t, stdout)
+
+	assert.Contains(t, defs, "CreateUserInput")
+	assert.Contains(t, defs, "AddressInput")
+	assert.Contains(t, defs, "Role")
+	assert.NotContains(t, defs, "User")
+	assert.NotContains(t, defs, "__TypeKind")
+}
+
+func TestConvert_JSONSchema_InputObject(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "jsonschema", "--type", "CreateUserInput", "-s", schemaPath})
+	require.NoError(t, err)
+	defs := jsonSchemaDefs(t, stdout)
+
+	input := defs["CreateUserInput"]
+	assert.Equal(t, "object", input["type"])
+	assert.Equal(t, "Input for creating a user", input["description"])
+	assert.Equal(t, false, input["additionalProperties"])
+	// Non-null fields with defaults are not required
+	assert.ElementsMatch(t, []any{"name", "scores"}, input["required"])
+
+	props := input["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "description": "Display name"}, props["name"])
+	assert.Equal(t, map[string]any{"type": "integer", "default": float64(18)}, props["age"])
+
+	// Nullable list of non-null strings
+	assert.Equal(t, map[string]any{
+		"type":  []any{"array", "null"},
+		"items": map[string]any{"type": "string"},
+	}, props["tags"])
+
+	// Non-null list of nullable floats
+	assert.Equal(t, map[string]any{
+		"type":  "array",
+		"items": map[string]any{"type": []any{"number", "null"}},
+	}, props["scores"])
+
+	// Nullable references accept null through anyOf
+	assert.Equal(t, map[string]any{
+		"anyOf":   []any{map[string]any{"$ref": "#/$defs/Role"}, map[string]any{"type": "null"}},
+		"default": "MEMBER",
+	}, props["role"])
+}
+
+func TestConvert_JSONSchema_SingleTypeOnlyIncludesDependencies(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "jsonschema", "--type", "AddressInput", "-s", schemaPath})
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+	assert.Equal(t, "#/$defs/AddressInput", doc["$ref"])
+
+	// Recursive inputs terminate and only the type itself is included
+	defs := doc["$defs"].(map[string]any)
+	assert.Len(t, defs, 1)
+	assert.Contains(t, defs, "AddressInput")
+}
+
+func TestConvert_JSONSchema_EnumAndCustomScalar(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"convert", "--to", "jsonschema", "--type", "CreateUserInput", "-s", schemaPath})
+	require.NoError(t, err)
+	defs := jsonSchemaDefs(t, stdout)
+
+	assert.Equal(t, map[string]any{
+		"title":       "Role",
+		"description": "User roles",
+		"type":        "string",
+		"enum":        []any{"ADMIN", "MEMBER", "GUEST"},
+	}, defs["Role"])
+
+	// Custom scalars accept any value
+	assert.Equal(t, map[string]any{"title": "DateTime"}, defs["DateTime"])
+}
+
+func TestConvert_Errors(t *testing.T) {
+	schemaPath := setupConvertTestSchema(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"missing target", []string{"convert"}, `required flag(s) "to" not set`},
+		{"invalid target", []string{"convert", "--to", "yaml"}, "--to must be"},
+		{"type with introspection", []string{"convert", "--to", "introspection", "--type", "Role"}, "--type can only be used with --to jsonschema"},
+		{"unknown type", []string{"convert", "--to", "jsonschema", "--type", "CreateUserInpt"}, "did you mean 'CreateUserInput'"},
+		{"output type", []string{"convert", "--to", "jsonschema", "--type", "User"}, "'User' is not an input or enum (it's a type)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := cmd.ExecuteWithArgs(append(tt.args, "-s", schemaPath))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
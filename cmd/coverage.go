@@ -0,0 +1,172 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type coverageOptions struct {
+	operations string
+	typeName   string
+	unused     bool
+	sort       string
+}
+
+var validCoverageSorts = []string{"field", "usages"}
+
+func formatCoverageText(c CoverageInfo) string {
+	return fmt.Sprintf("%s: %d", c.Field, c.Usages)
+}
+
+func formatCoveragePretty(coverage []CoverageInfo) string {
+	t := makeTable()
+
+	for _, c := range coverage {
+		t.Row(c.Field, strconv.Itoa(c.Usages))
+	}
+	t.Headers("field", "usages")
+
+	return t.String()
+}
+
+// fieldCoverage returns the usages of every object and interface field.
+// Selecting a field through an interface also counts as a usage of that field
+// on each implementing type, since any of them can resolve it.
+func fieldCoverage(schema *ast.Schema, usages schemaUsages) []CoverageInfo {
+	var coverage []CoverageInfo
+	for _, def := range userTypes(schema) {
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			continue
+		}
+		for _, field := range userFields(def) {
+			usedIn := usages.usedIn(schema, def.Name+"."+field.Name)
+			coverage = append(coverage, CoverageInfo{
+				Field:  def.Name + "." + field.Name,
+				Usages: len(usedIn),
+				UsedIn: usedIn,
+			})
+		}
+	}
+	return coverage
+}
+
+func NewCoverageCmd() *cobra.Command {
+	opts := &coverageOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "coverage --operations <dir>",
+		Short: "Shows how often operations use each schema field",
+		Long: `Shows how often a set of operation documents use each field of the schema's
+object and interface types.
+
+Every .graphql and .gql file in the --operations directory is validated
+against the schema, and each selection is resolved to the Type.field it
+selects, including selections inside fragments. Fragments are counted once
+where they are defined. Selecting a field through an interface counts as a
+usage of the interface field and of that field on every implementing type.
+
+A summary of how many fields are used is written to stderr.
+
+Output formats:
+  text    "User.name: 2" (default when piping)
+  json    [{"field": "User.name", "usages": 2, "usedIn": ["src/me.graphql:3:5", ...]}, ...]
+          Types are sorted by name and fields kept in schema order, so the
+          output can be diffed over time
+  pretty  Formatted table with columns (default in terminal)`,
+		Example: `  # Show field usage across all client operations
+  gqlx coverage --operations ./src
+
+  # Find fields no client queries
+  gqlx coverage --operations ./src --unused
+
+  # Show the most used fields of the User type
+  gqlx coverage --operations ./src --type User --sort usages
+
+  # Save a snapshot to diff later
+  gqlx coverage --operations ./src -f json > coverage.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCoverage(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.operations, "operations", "", "Directory (or file) of operation documents")
+	cmd.Flags().StringVar(&opts.typeName, "type", "", "Only show fields of the specified type")
+	cmd.Flags().BoolVar(&opts.unused, "unused", false, "Only show fields no operation uses")
+	cmd.Flags().StringVar(&opts.sort, "sort", "field", "Sort by: "+strings.Join(validCoverageSorts, ", "))
+	_ = cmd.MarkFlagRequired("operations")
+
+	return cmd
+}
+
+func runCoverage(cmd *cobra.Command, opts *coverageOptions) error {
+	if !slices.Contains(validCoverageSorts, opts.sort) {
+		return fmt.Errorf("--sort must be one of %s, got '%s'", strings.Join(validCoverageSorts, ", "), opts.sort)
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	if opts.typeName != "" {
+		if err := validateTypeExists(schema, opts.typeName, "type"); err != nil {
+			return err
+		}
+		if def := schema.Types[opts.typeName]; def.Kind != ast.Object && def.Kind != ast.Interface {
+			return fmt.Errorf("'%s' is not an object or interface type (it's a %s)", opts.typeName, kindToString(string(def.Kind)))
+		}
+	}
+
+	usages, err := loadOperationUsages(cmd, schema, opts.operations)
+	if err != nil {
+		return err
+	}
+
+	coverage := fieldCoverage(schema, usages)
+
+	used := 0
+	for _, c := range coverage {
+		if c.Usages > 0 {
+			used++
+		}
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "%d of %d fields used (%s%%)\n", used, len(coverage), strconv.FormatFloat(percentage(used, len(coverage)), 'f', -1, 64))
+
+	coverage = filterSlice(coverage, func(c CoverageInfo) bool {
+		if opts.typeName != "" && !strings.HasPrefix(c.Field, opts.typeName+".") {
+			return false
+		}
+		return !opts.unused || c.Usages == 0
+	})
+
+	if opts.sort == "usages" {
+		slices.SortStableFunc(coverage, func(a, b CoverageInfo) int {
+			return cmp.Compare(b.Usages, a.Usages)
+		})
+	}
+
+	renderer := render.Renderer[CoverageInfo]{
+		Data:         coverage,
+		TextFormat:   formatCoverageText,
+		PrettyFormat: formatCoveragePretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
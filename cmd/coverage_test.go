@@ -0,0 +1,196 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coverageTestSchema = `
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	email: String
+	posts: [Post!]!
+}
+
+type Post implements Node {
+	id: ID!
+	title: String
+	body: String
+}
+
+input PostFilter {
+	title: String
+}
+
+type Query {
+	me: User
+	node(id: ID!): Node
+	posts(filter: PostFilter): [Post!]!
+}
+`
+
+type coverageInfo struct {
+	Field  string   `json:"field"`
+	Usages int      `json:"usages"`
+	UsedIn []string `json:"usedIn"`
+}
+
+func setupCoverageTest(t *testing.T, operations map[string]string) (schemaPath, opsDir string) {
+	t.Helper()
+	dir := t.TempDir()
+	schemaPath = filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(coverageTestSchema), 0644))
+
+	opsDir = filepath.Join(dir, "src")
+	for name, content := range operations {
+		path := filepath.Join(opsDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return schemaPath, opsDir
+}
+
+func runCoverageJSON(t *testing.T, schemaPath, opsDir string, args ...string) []coverageInfo {
+	t.Helper()
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"coverage", "-s", schemaPath, "--operations", opsDir, "-f", "json"}, args...))
+	require.NoError(t, err)
+
+	var coverage []coverageInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &coverage))
+	return coverage
+}
+
+func coverageCounts(coverage []coverageInfo) map[string]int {
+	counts := map[string]int{}
+	for _, c := range coverage {
+		counts[c.Field] = c.Usages
+	}
+	return counts
+}
+
+func TestCoverage_CountsFieldsThroughFragmentsAndInterfaces(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"me.graphql": `query Me { me { name ...UserPosts } }
+
+fragment UserPosts on User {
+	posts { title }
+}`,
+		"nested/node.gql": `query Node($id: ID!) {
+	node(id: $id) {
+		id
+		... on Post { title }
+	}
+}`,
+	})
+
+	coverage := runCoverageJSON(t, schemaPath, opsDir)
+
+	assert.Equal(t, map[string]int{
+		"Node.id":     1,
+		"Post.body":   0,
+		"Post.id":     1, // Selected through the Node interface
+		"Post.title":  2,
+		"Query.me":    1,
+		"Query.node":  1,
+		"Query.posts": 0,
+		"User.email":  0,
+		"User.id":     1, // Selected through the Node interface
+		"User.name":   1,
+		"User.posts":  1,
+	}, coverageCounts(coverage))
+
+	// Types are sorted by name, fields stay in schema order
+	assert.Equal(t, "Node.id", coverage[0].Field)
+	assert.Equal(t, "Post.id", coverage[1].Field)
+	assert.Equal(t, "Post.title", coverage[2].Field)
+
+	assert.Equal(t, []string{
+		filepath.Join(opsDir, "me.graphql") + ":4:10",
+		filepath.Join(opsDir, "nested", "node.gql") + ":4:17",
+	}, coverage[2].UsedIn)
+}
+
+func TestCoverage_Unused(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"me.graphql": `{ me { id name email posts { id title body } } }`,
+	})
+
+	coverage := runCoverageJSON(t, schemaPath, opsDir, "--unused")
+
+	assert.Equal(t, map[string]int{
+		"Node.id":     0,
+		"Query.node":  0,
+		"Query.posts": 0,
+	}, coverageCounts(coverage))
+}
+
+func TestCoverage_TypeFilterAndSortByUsages(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"a.graphql": `{ me { name email } }`,
+		"b.graphql": `{ me { name } }`,
+	})
+
+	coverage := runCoverageJSON(t, schemaPath, opsDir, "--type", "User", "--sort", "usages")
+
+	var fields []string
+	for _, c := range coverage {
+		fields = append(fields, c.Field)
+	}
+	assert.Equal(t, []string{"User.name", "User.email", "User.id", "User.posts"}, fields)
+}
+
+func TestCoverage_SummaryAndTextFormat(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"me.graphql": `{ me { name } }`,
+	})
+
+	stdout, stderr, err := cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath, "--operations", opsDir, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "User.name: 1\n")
+	assert.Contains(t, stdout, "User.email: 0\n")
+	assert.Contains(t, stderr, "2 of 11 fields used (18.2%)")
+}
+
+func TestCoverage_RequiresOperations(t *testing.T) {
+	schemaPath, _ := setupCoverageTest(t, nil)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `required flag(s) "operations" not set`)
+}
+
+func TestCoverage_InvalidType(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"me.graphql": `{ me { name } }`,
+	})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath, "--operations", opsDir, "--type", "Usr"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'User'?")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath, "--operations", opsDir, "--type", "PostFilter"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'PostFilter' is not an object or interface type (it's a input)")
+}
+
+func TestCoverage_InvalidOperation(t *testing.T) {
+	schemaPath, opsDir := setupCoverageTest(t, map[string]string{
+		"me.graphql": `{ me { nmae } }`,
+	})
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath, "--operations", opsDir})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not valid against the schema")
+}
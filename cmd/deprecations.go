@@ -0,0 +1,366 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type deprecationsOptions struct {
+	kind       string
+	sort       string
+	overdue    string
+	operations string
+	unused     bool
+}
+
+// Deprecation kinds reported by the deprecations command.
+const (
+	deprecationKindField      = "field"
+	deprecationKindArgument   = "argument"
+	deprecationKindInputField = "input-field"
+	deprecationKindEnumValue  = "enum-value"
+)
+
+var validDeprecationKinds = []string{
+	deprecationKindField,
+	deprecationKindArgument,
+	deprecationKindInputField,
+	deprecationKindEnumValue,
+}
+
+var validDeprecationSorts = []string{"location", "kind", "removal", "usages"}
+
+const dateLayout = "2006-01-02"
+
+var (
+	removalWordPattern    = regexp.MustCompile(`(?i)\b(?:remov|drop|delet|sunset)\w*|\b(?:until|after|before)\b`)
+	removalDatePattern    = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`)
+	removalVersionPattern = regexp.MustCompile(`\bv\d+(?:\.\d+){0,2}\b|\b\d+\.\d+(?:\.\d+)?\b`)
+)
+
+// removalPlan returns the part of a deprecation reason after the first word
+// announcing a removal ("removed", "drop", "until", ...), or "" if there is
+// none. Dates and versions before it, like when the deprecation was made,
+// are not part of the plan.
+func removalPlan(reason string) string {
+	loc := removalWordPattern.FindStringIndex(reason)
+	if loc == nil {
+		return ""
+	}
+	return reason[loc[1]:]
+}
+
+// parseRemovalDate returns the first valid YYYY-MM-DD date in the removal
+// plan of a deprecation reason.
+func parseRemovalDate(reason string) string {
+	for _, match := range removalDatePattern.FindAllString(removalPlan(reason), -1) {
+		if _, err := time.Parse(dateLayout, match); err == nil {
+			return match
+		}
+	}
+	return ""
+}
+
+// parseRemovalVersion returns the first version-like token ("v3", "2.0",
+// "1.4.2") in the removal plan of a deprecation reason, ignoring dates.
+func parseRemovalVersion(reason string) string {
+	return removalVersionPattern.FindString(removalDatePattern.ReplaceAllString(removalPlan(reason), ""))
+}
+
+// compareVersions compares dotted versions numerically, treating missing parts
+// as zero and ignoring a leading "v".
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := range max(len(as), len(bs)) {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareRemovals orders deprecations by removal date, then by removal
+// version, with deprecations that have neither last.
+func compareRemovals(a, b DeprecationInfo) int {
+	rank := func(d DeprecationInfo) int {
+		switch {
+		case d.RemovalDate != "":
+			return 0
+		case d.RemovalVersion != "":
+			return 1
+		}
+		return 2
+	}
+	return cmp.Or(
+		cmp.Compare(rank(a), rank(b)),
+		strings.Compare(a.RemovalDate, b.RemovalDate),
+		compareVersions(a.RemovalVersion, b.RemovalVersion),
+	)
+}
+
+func formatDeprecationRemoval(d DeprecationInfo) string {
+	var parts []string
+	if d.RemovalDate != "" {
+		parts = append(parts, d.RemovalDate)
+	}
+	if d.RemovalVersion != "" {
+		parts = append(parts, d.RemovalVersion)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatDeprecationText(d DeprecationInfo) string {
+	text := fmt.Sprintf("%s: %s", d.Location, strings.ReplaceAll(d.Reason, "\n", " "))
+	if removal := formatDeprecationRemoval(d); removal != "" {
+		text += fmt.Sprintf(" (removal: %s)", removal)
+	}
+	if d.Usages != nil {
+		text += fmt.Sprintf(" [usages: %d]", *d.Usages)
+	}
+	return text
+}
+
+func formatDeprecationsPretty(deprecations []DeprecationInfo) string {
+	t := makeTable()
+
+	withUsages := len(deprecations) > 0 && deprecations[0].Usages != nil
+	for _, d := range deprecations {
+		row := []string{d.Location, d.Kind, strings.ReplaceAll(d.Reason, "\n", " "), formatDeprecationRemoval(d)}
+		if withUsages {
+			row = append(row, strconv.Itoa(*d.Usages))
+		}
+		t.Row(row...)
+	}
+	if withUsages {
+		t.Headers("location", "kind", "reason", "removal", "usages")
+	} else {
+		t.Headers("location", "kind", "reason", "removal")
+	}
+
+	return t.String()
+}
+
+// findDeprecations returns every deprecated field, argument, input field and
+// enum value in the schema, including arguments of custom directives.
+func findDeprecations(schema *ast.Schema) []DeprecationInfo {
+	var deprecations []DeprecationInfo
+	add := func(location, kind string, directives ast.DirectiveList) {
+		reason, ok := deprecationReason(directives)
+		if !ok {
+			return
+		}
+		deprecations = append(deprecations, DeprecationInfo{
+			Location:       location,
+			Kind:           kind,
+			Reason:         reason,
+			RemovalDate:    parseRemovalDate(reason),
+			RemovalVersion: parseRemovalVersion(reason),
+		})
+	}
+
+	for _, def := range userTypes(schema) {
+		switch def.Kind {
+		case ast.Object, ast.Interface:
+			for _, field := range userFields(def) {
+				add(def.Name+"."+field.Name, deprecationKindField, field.Directives)
+				for _, arg := range field.Arguments {
+					add(def.Name+"."+field.Name+"."+arg.Name, deprecationKindArgument, arg.Directives)
+				}
+			}
+		case ast.InputObject:
+			for _, field := range def.Fields {
+				add(def.Name+"."+field.Name, deprecationKindInputField, field.Directives)
+			}
+		case ast.Enum:
+			for _, value := range def.EnumValues {
+				add(def.Name+"."+value.Name, deprecationKindEnumValue, value.Directives)
+			}
+		}
+	}
+
+	for _, directive := range schema.Directives {
+		if directive.Position != nil && directive.Position.Src != nil && directive.Position.Src.BuiltIn {
+			continue
+		}
+		for _, arg := range directive.Arguments {
+			add("@"+directive.Name+"."+arg.Name, deprecationKindArgument, arg.Directives)
+		}
+	}
+
+	slices.SortFunc(deprecations, func(a, b DeprecationInfo) int {
+		return strings.Compare(a.Location, b.Location)
+	})
+	return deprecations
+}
+
+func NewDeprecationsCmd() *cobra.Command {
+	opts := &deprecationsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "deprecations",
+		Short: "Lists everything deprecated in the schema with its reason",
+		Long: `Lists every deprecated field, argument, input field and enum value in the
+schema, along with the reason given in @deprecated.
+
+Deprecation kinds:
+  field        an object or interface field
+  argument     a field or directive argument
+  input-field  an input object field
+  enum-value   an enum value
+
+Removal plans are read from the reason, after the first word announcing a
+removal (remove, drop, delete, sunset, until, after or before): the first
+YYYY-MM-DD date after it is the removal date, and the first version-like
+token ("v3", "2.0", "1.4.2") is the removal version. For example "Use
+displayName. Removal on 2026-06-01" or "Deprecated in v2, removed in v3".
+
+With --operations, the .graphql and .gql files in a directory are validated
+against the schema and each deprecation shows how often they use it. Enum
+values and input fields are only counted when written inline in a document,
+not when passed in variables.
+
+Output formats:
+  text    "User.name: Use displayName (removal: 2026-06-01) [usages: 2]"
+          (default when piping)
+  json    [{"location": "User.name", "kind": "field", "reason": "...",
+          "removalDate": "2026-06-01", "usages": 2, "usedIn": [...]}, ...]
+  pretty  Formatted table with columns (default in terminal)`,
+		Example: `  # List everything that is deprecated
+  gqlx deprecations
+
+  # Plan removals in the order they are due
+  gqlx deprecations --sort removal
+
+  # Find deprecations whose removal date has passed
+  gqlx deprecations --overdue today
+
+  # Check what the client still uses before removing it
+  gqlx deprecations --operations ./src --sort usages
+
+  # List deprecations that are safe to remove
+  gqlx deprecations --operations ./src --unused`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDeprecations(cmd, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.kind, "kind", "", "Filter by deprecation kind: "+strings.Join(validDeprecationKinds, ", "))
+	cmd.Flags().StringVar(&opts.sort, "sort", "location", "Sort by: "+strings.Join(validDeprecationSorts, ", "))
+	cmd.Flags().StringVar(&opts.overdue, "overdue", "", "Only show deprecations with a removal date before this date (YYYY-MM-DD or \"today\")")
+	cmd.Flags().StringVar(&opts.operations, "operations", "", "Directory (or file) of operation documents to count usages in")
+	cmd.Flags().BoolVar(&opts.unused, "unused", false, "Only show deprecations not used by any operation (requires --operations)")
+
+	return cmd
+}
+
+func runDeprecations(cmd *cobra.Command, opts *deprecationsOptions) error {
+	if opts.kind != "" && !slices.Contains(validDeprecationKinds, opts.kind) {
+		return fmt.Errorf("--kind must be one of %s, got '%s'", strings.Join(validDeprecationKinds, ", "), opts.kind)
+	}
+	if !slices.Contains(validDeprecationSorts, opts.sort) {
+		return fmt.Errorf("--sort must be one of %s, got '%s'", strings.Join(validDeprecationSorts, ", "), opts.sort)
+	}
+	if opts.operations == "" && (opts.unused || opts.sort == "usages") {
+		return fmt.Errorf("--unused and --sort usages require --operations")
+	}
+
+	overdue := ""
+	switch opts.overdue {
+	case "":
+	case "today":
+		overdue = time.Now().Format(dateLayout)
+	default:
+		if _, err := time.Parse(dateLayout, opts.overdue); err != nil {
+			return fmt.Errorf("--overdue must be a YYYY-MM-DD date or \"today\", got '%s'", opts.overdue)
+		}
+		overdue = opts.overdue
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	var usages schemaUsages
+	if opts.operations != "" {
+		usages, err = loadOperationUsages(cmd, schema, opts.operations)
+		if err != nil {
+			return err
+		}
+	}
+
+	deprecations := filterSlice(findDeprecations(schema), func(d DeprecationInfo) bool {
+		if opts.kind != "" && d.Kind != opts.kind {
+			return false
+		}
+		// Dates are YYYY-MM-DD, so they compare correctly as strings
+		if overdue != "" && (d.RemovalDate == "" || d.RemovalDate >= overdue) {
+			return false
+		}
+		if opts.unused && len(usages.usedIn(schema, d.Location)) > 0 {
+			return false
+		}
+		return true
+	})
+
+	if usages != nil {
+		for i := range deprecations {
+			usedIn := usages.usedIn(schema, deprecations[i].Location)
+			count := len(usedIn)
+			deprecations[i].Usages = &count
+			deprecations[i].UsedIn = usedIn
+		}
+	}
+
+	switch opts.sort {
+	case "kind":
+		slices.SortStableFunc(deprecations, func(a, b DeprecationInfo) int {
+			return cmp.Compare(slices.Index(validDeprecationKinds, a.Kind), slices.Index(validDeprecationKinds, b.Kind))
+		})
+	case "removal":
+		slices.SortStableFunc(deprecations, compareRemovals)
+	case "usages":
+		slices.SortStableFunc(deprecations, func(a, b DeprecationInfo) int {
+			return cmp.Compare(*b.Usages, *a.Usages)
+		})
+	}
+
+	if len(deprecations) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No deprecations found that match the filters.")
+	}
+
+	renderer := render.Renderer[DeprecationInfo]{
+		Data:         deprecations,
+		TextFormat:   formatDeprecationText,
+		PrettyFormat: formatDeprecationsPretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,309 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deprecationsTestSchema = `
+type User {
+	id: ID!
+	name: String @deprecated(reason: "Use displayName. Removal on 2025-06-01")
+	displayName: String
+	posts(first: Int, after: String @deprecated(reason: "Use cursor, removed in v3")): [String]
+	legacy: Boolean @deprecated
+}
+
+enum Role {
+	ADMIN
+	MEMBER @deprecated(reason: "Removed in 2.1")
+}
+
+input UserFilter {
+	role: Role
+	old: String @deprecated(reason: "Drop after 2027-01-01")
+}
+
+directive @auth(role: Role @deprecated) on FIELD_DEFINITION
+
+type Query {
+	me: User
+	users(filter: UserFilter): [User]
+}
+`
+
+type deprecationInfo struct {
+	Location       string   `json:"location"`
+	Kind           string   `json:"kind"`
+	Reason         string   `json:"reason"`
+	RemovalDate    string   `json:"removalDate"`
+	RemovalVersion string   `json:"removalVersion"`
+	Usages         *int     `json:"usages"`
+	UsedIn         []string `json:"usedIn"`
+}
+
+func setupDeprecationsTest(t *testing.T) (dir, schemaPath string) {
+	t.Helper()
+	dir = t.TempDir()
+	schemaPath = filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(deprecationsTestSchema), 0644))
+	return dir, schemaPath
+}
+
+func runDeprecationsJSON(t *testing.T, schemaPath string, args ...string) []deprecationInfo {
+	t.Helper()
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"deprecations", "-s", schemaPath, "-f", "json"}, args...))
+	require.NoError(t, err)
+
+	var deprecations []deprecationInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &deprecations))
+	return deprecations
+}
+
+func deprecationLocations(deprecations []deprecationInfo) []string {
+	var locations []string
+	for _, d := range deprecations {
+		locations = append(locations, d.Location)
+	}
+	return locations
+}
+
+func TestDeprecations_ListsAllKindsWithReasons(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	deprecations := runDeprecationsJSON(t, schemaPath)
+
+	assert.Equal(t, []deprecationInfo{
+		{Location: "@auth.role", Kind: "argument", Reason: "No longer supported"},
+		{Location: "Role.MEMBER", Kind: "enum-value", Reason: "Removed in 2.1", RemovalVersion: "2.1"},
+		{Location: "User.legacy", Kind: "field", Reason: "No longer supported"},
+		{Location: "User.name", Kind: "field", Reason: "Use displayName. Removal on 2025-06-01", RemovalDate: "2025-06-01"},
+		{Location: "User.posts.after", Kind: "argument", Reason: "Use cursor, removed in v3", RemovalVersion: "v3"},
+		{Location: "UserFilter.old", Kind: "input-field", Reason: "Drop after 2027-01-01", RemovalDate: "2027-01-01"},
+	}, deprecations)
+}
+
+func TestDeprecations_TextFormat(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"deprecations", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "User.name: Use displayName. Removal on 2025-06-01 (removal: 2025-06-01)\n")
+	assert.Contains(t, stdout, "User.legacy: No longer supported\n")
+}
+
+func TestDeprecations_FilterByKind(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--kind", "argument")
+
+	assert.Equal(t, []string{"@auth.role", "User.posts.after"}, deprecationLocations(deprecations))
+}
+
+func TestDeprecations_SortByRemoval(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--sort", "removal")
+
+	// Dates first, then versions, then deprecations without a removal plan
+	assert.Equal(t, []string{
+		"User.name",
+		"UserFilter.old",
+		"Role.MEMBER",
+		"User.posts.after",
+		"@auth.role",
+		"User.legacy",
+	}, deprecationLocations(deprecations))
+}
+
+func TestDeprecations_SortByKind(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--sort", "kind")
+
+	assert.Equal(t, []string{
+		"User.legacy",
+		"User.name",
+		"@auth.role",
+		"User.posts.after",
+		"UserFilter.old",
+		"Role.MEMBER",
+	}, deprecationLocations(deprecations))
+}
+
+func TestDeprecations_Overdue(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--overdue", "2026-01-01")
+	assert.Equal(t, []string{"User.name"}, deprecationLocations(deprecations))
+
+	deprecations = runDeprecationsJSON(t, schemaPath, "--overdue", "2030-01-01")
+	assert.Equal(t, []string{"User.name", "UserFilter.old"}, deprecationLocations(deprecations))
+}
+
+func TestDeprecations_RemovalPlanAfterRemovalWording(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+type Query {
+	a: Int @deprecated(reason: "Deprecated on 2024-01-01; removal after 2027-06-01")
+	b: Int @deprecated(reason: "Deprecated since v2, removed in v3")
+	c: Int @deprecated(reason: "Use fastC, 1.5 times faster")
+	d: Int @deprecated(reason: "Deprecated in 2.0 on 2024-03-01")
+}
+`)
+
+	deprecations := runDeprecationsJSON(t, schemaPath)
+	assert.Equal(t, []deprecationInfo{
+		{Location: "Query.a", Kind: "field", Reason: "Deprecated on 2024-01-01; removal after 2027-06-01", RemovalDate: "2027-06-01"},
+		{Location: "Query.b", Kind: "field", Reason: "Deprecated since v2, removed in v3", RemovalVersion: "v3"},
+		{Location: "Query.c", Kind: "field", Reason: "Use fastC, 1.5 times faster"},
+		{Location: "Query.d", Kind: "field", Reason: "Deprecated in 2.0 on 2024-03-01"},
+	}, deprecations)
+
+	deprecations = runDeprecationsJSON(t, schemaPath, "--overdue", "2026-10-18")
+	assert.Empty(t, deprecations)
+}
+
+func TestDeprecations_InvalidOverdueDate(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"deprecations", "-s", schemaPath, "--overdue", "next week"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--overdue must be a YYYY-MM-DD date")
+}
+
+func TestDeprecations_InvalidKind(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"deprecations", "-s", schemaPath, "--kind", "type"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--kind must be one of")
+}
+
+func TestDeprecations_UsagesInOperations(t *testing.T) {
+	dir, schemaPath := setupDeprecationsTest(t)
+	opsDir := filepath.Join(dir, "src")
+	require.NoError(t, os.MkdirAll(filepath.Join(opsDir, "nested"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(opsDir, "me.graphql"), []byte(strings.TrimSpace(`
+query Me {
+  me {
+    name
+    posts(after: "abc")
+  }
+}
+`)), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(opsDir, "nested", "users.gql"), []byte(strings.TrimSpace(`
+query Users {
+  users(filter: {role: MEMBER, old: "x"}) {
+    ...UserName
+  }
+  more: users {
+    ...UserName
+  }
+}
+
+fragment UserName on User {
+  name
+}
+`)), 0644))
+	// Files with other extensions are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(opsDir, "notes.txt"), []byte("not graphql"), 0644))
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--operations", opsDir)
+
+	usages := map[string]int{}
+	for _, d := range deprecations {
+		require.NotNil(t, d.Usages, d.Location)
+		usages[d.Location] = *d.Usages
+	}
+	assert.Equal(t, map[string]int{
+		"@auth.role":       0,
+		"Role.MEMBER":      1,
+		"User.legacy":      0,
+		"User.name":        2, // The fragment is counted once, not at each spread
+		"User.posts.after": 1,
+		"UserFilter.old":   1,
+	}, usages)
+
+	for _, d := range deprecations {
+		if d.Location == "User.name" {
+			assert.Equal(t, []string{
+				filepath.Join(opsDir, "me.graphql") + ":3:5",
+				filepath.Join(opsDir, "nested", "users.gql") + ":11:3",
+			}, d.UsedIn)
+		}
+	}
+}
+
+func TestDeprecations_UnusedAndSortByUsages(t *testing.T) {
+	dir, schemaPath := setupDeprecationsTest(t)
+	opsPath := filepath.Join(dir, "ops.graphql")
+	require.NoError(t, os.WriteFile(opsPath, []byte(`{ me { name legacy } a: me { name } }`), 0644))
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--operations", opsPath, "--unused")
+	assert.Equal(t, []string{"@auth.role", "Role.MEMBER", "User.posts.after", "UserFilter.old"}, deprecationLocations(deprecations))
+
+	deprecations = runDeprecationsJSON(t, schemaPath, "--operations", opsPath, "--sort", "usages")
+	assert.Equal(t, []string{"User.name", "User.legacy"}, deprecationLocations(deprecations)[:2])
+}
+
+func TestDeprecations_UsagesThroughInterface(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`
+interface Named {
+	name(short: Boolean): String
+}
+
+type User implements Named {
+	name(short: Boolean @deprecated): String @deprecated
+}
+
+type Query {
+	named: Named
+}
+`), 0644))
+	opsPath := filepath.Join(dir, "ops.graphql")
+	require.NoError(t, os.WriteFile(opsPath, []byte(`{ named { name(short: true) } }`), 0644))
+
+	deprecations := runDeprecationsJSON(t, schemaPath, "--operations", opsPath)
+	require.Len(t, deprecations, 2)
+	for _, d := range deprecations {
+		require.NotNil(t, d.Usages, d.Location)
+		assert.Equal(t, 1, *d.Usages, d.Location)
+	}
+
+	deprecations = runDeprecationsJSON(t, schemaPath, "--operations", opsPath, "--unused")
+	assert.Empty(t, deprecations)
+
+	// Coverage counts the same usage
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"coverage", "-s", schemaPath, "--operations", opsPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "User.name: 1")
+}
+
+func TestDeprecations_UnusedRequiresOperations(t *testing.T) {
+	_, schemaPath := setupDeprecationsTest(t)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"deprecations", "-s", schemaPath, "--unused"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "require --operations")
+}
+
+func TestDeprecations_InvalidOperation(t *testing.T) {
+	dir, schemaPath := setupDeprecationsTest(t)
+	opsPath := filepath.Join(dir, "ops.graphql")
+	require.NoError(t, os.WriteFile(opsPath, []byte(`{ me { nmae } }`), 0644))
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"deprecations", "-s", schemaPath, "--operations", opsPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not valid against the schema")
+	assert.Contains(t, stderr, "nmae")
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+func formatEntityKey(key EntityKey) string {
+	if !key.Resolvable {
+		return fmt.Sprintf("@key(fields: %s, resolvable: false)", strconv.Quote(key.Fields))
+	}
+	return fmt.Sprintf("@key(fields: %s)", strconv.Quote(key.Fields))
+}
+
+func formatEntityKeys(e EntityInfo) string {
+	keys := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		keys[i] = formatEntityKey(key)
+	}
+	return strings.Join(keys, " ")
+}
+
+func formatEntityText(e EntityInfo) string {
+	return e.Name + " " + formatEntityKeys(e)
+}
+
+func formatEntitiesPretty(entities []EntityInfo) string {
+	t := makeTable()
+
+	for _, e := range entities {
+		t.Row(e.Name, e.Kind, formatEntityKeys(e))
+	}
+	t.Headers("name", "kind", "keys")
+
+	return t.String()
+}
+
+// checkKeyFields returns a problem with a @key field set, such as a field that
+// does not exist on the entity, or "" if it selects valid fields.
+func checkKeyFields(schema *ast.Schema, def *ast.Definition, fields string) string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: "{" + fields + "}"})
+	if err != nil || len(doc.Operations) != 1 {
+		return "invalid field set"
+	}
+
+	var check func(def *ast.Definition, selectionSet ast.SelectionSet) string
+	check = func(def *ast.Definition, selectionSet ast.SelectionSet) string {
+		for _, selection := range selectionSet {
+			field, ok := selection.(*ast.Field)
+			if !ok {
+				continue
+			}
+			fieldDef := def.Fields.ForName(field.Name)
+			if fieldDef == nil {
+				problem := fmt.Sprintf("%s has no field '%s'", def.Name, field.Name)
+				if suggestion := findClosest(field.Name, pluck(def.Fields, func(f *ast.FieldDefinition) string { return f.Name })); suggestion != "" {
+					problem += fmt.Sprintf(", did you mean '%s'?", suggestion)
+				}
+				return problem
+			}
+			if len(field.SelectionSet) > 0 {
+				if problem := check(schema.Types[fieldDef.Type.Name()], field.SelectionSet); problem != "" {
+					return problem
+				}
+			}
+		}
+		return ""
+	}
+
+	return check(def, doc.Operations[0].SelectionSet)
+}
+
+func NewEntitiesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "entities",
+		Short: "Lists Apollo Federation entity types with their key fields",
+		Long: `Lists the entity types of an Apollo Federation subgraph: the object and
+interface types with a @key directive, along with their key field sets.
+
+The schema is always loaded in federation mode (see --federation). Key field
+sets that select fields missing from the entity are reported as warnings.
+
+Output formats:
+  text    User @key(fields: "id") @key(fields: "email", resolvable: false)
+          (default when piping)
+  json    [{"name": "User", "kind": "type", "keys": [{"fields": "id", "resolvable": true}, ...]}, ...]
+  pretty  Formatted table with columns (default in terminal)`,
+		Example: `  # List the entities of a subgraph
+  gqlx entities -s products.graphql
+
+  # Find the paths that reach an entity, including through _entities
+  gqlx paths Product --federation`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEntities(cmd)
+		},
+	}
+
+	return cmd
+}
+
+func runEntities(cmd *cobra.Command) error {
+	federation = true
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	var entities []EntityInfo
+	for _, def := range userTypes(schema) {
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			continue
+		}
+
+		entity := EntityInfo{Name: def.Name, Kind: kindToString(string(def.Kind))}
+		for _, directive := range def.Directives {
+			if !isKeyDirective(directive) {
+				continue
+			}
+			key := EntityKey{Resolvable: true}
+			if fields := directive.Arguments.ForName("fields"); fields != nil {
+				key.Fields = fields.Value.Raw
+			}
+			if resolvable := directive.Arguments.ForName("resolvable"); resolvable != nil && resolvable.Value.Raw == "false" {
+				key.Resolvable = false
+			}
+			if problem := checkKeyFields(schema, def, key.Fields); problem != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s %s: %s\n", def.Name, formatEntityKey(key), problem)
+			}
+			entity.Keys = append(entity.Keys, key)
+		}
+
+		if len(entity.Keys) > 0 {
+			entities = append(entities, entity)
+		}
+	}
+
+	if len(entities) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No entities found.")
+	}
+
+	renderer := render.Renderer[EntityInfo]{
+		Data:         entities,
+		TextFormat:   formatEntityText,
+		PrettyFormat: formatEntitiesPretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entityKey struct {
+	Fields     string `json:"fields"`
+	Resolvable bool   `json:"resolvable"`
+}
+
+type entityInfo struct {
+	Name string      `json:"name"`
+	Kind string      `json:"kind"`
+	Keys []entityKey `json:"keys"`
+}
+
+func TestEntities_ListsEntitiesWithKeys(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV1Subgraph)
+
+	// Entities always load the schema in federation mode
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"entities", "-s", schemaPath, "-f", "json"})
+	require.NoError(t, err)
+
+	var entities []entityInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &entities))
+	assert.Equal(t, []entityInfo{
+		{Name: "Product", Kind: "type", Keys: []entityKey{{Fields: "upc", Resolvable: true}}},
+		{Name: "User", Kind: "type", Keys: []entityKey{{Fields: "id", Resolvable: true}}},
+	}, entities)
+}
+
+func TestEntities_MultipleAndUnresolvableKeys(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV2Subgraph)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"entities", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "User @key(fields: \"id\") @key(fields: \"email\", resolvable: false)\n", stdout)
+}
+
+func TestEntities_InterfaceAndPrefixedKeys(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		extend schema @link(url: "https://specs.apollo.dev/federation/v2.3")
+
+		interface Media @federation__key(fields: "id") {
+			id: ID!
+		}
+
+		type Book implements Media @federation__key(fields: "id") @federation__key(fields: "isbn publisher { name }") {
+			id: ID!
+			isbn: String!
+			publisher: Publisher
+		}
+
+		type Publisher {
+			name: String
+		}
+
+		type Query {
+			media: [Media]
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"entities", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Book @key(fields: \"id\") @key(fields: \"isbn publisher { name }\")\nMedia @key(fields: \"id\")\n", stdout)
+}
+
+func TestEntities_WarnsAboutUnknownKeyFields(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		type User @key(fields: "uid") @key(fields: "org { nmae }") {
+			id: ID!
+			org: Org
+		}
+
+		type Org {
+			name: String
+		}
+
+		type Query {
+			me: User
+		}
+	`)
+
+	stdout, stderr, err := cmd.ExecuteWithArgs([]string{"entities", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "User @key(fields: \"uid\")")
+	assert.Contains(t, stderr, "warning: User @key(fields: \"uid\"): User has no field 'uid', did you mean 'id'?")
+	assert.Contains(t, stderr, "warning: User @key(fields: \"org { nmae }\"): Org has no field 'nmae', did you mean 'name'?")
+}
+
+func TestEntities_NoEntities(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		type Query {
+			version: String
+		}
+	`)
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"entities", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "No entities found.")
+}
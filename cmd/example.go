@@ -0,0 +1,263 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+type exampleOptions struct {
+	all        bool
+	seed       int64
+	listLength int
+	scalars    string
+	operation  string
+}
+
+func NewExampleCmd() *cobra.Command {
+	opts := &exampleOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "example <input type | operation file>",
+		Short: "Generates example JSON for an input type or an operation's variables",
+		Long: `Generates an example JSON value for an input object type, or for the
+variables of an operation document.
+
+By default only required fields and variables are included: non-null ones
+without a default value. Use --all to fill in every field. Enums use values
+from the schema, built-in scalars get placeholder values and custom scalars
+can be configured with the same --scalars file as gqlx mock. Values are
+derived from --seed, so the same seed always produces the same example.
+
+Recursive input types stop at the first nullable field that would repeat a
+type. Input types marked @oneOf get exactly one field.
+
+The example is checked with the same variables validation as gqlx mock run
+before it is printed. The --format flag is ignored; output is always JSON.`,
+		Example: `  # Build the input for a mutation
+  gqlx example CreateUserInput
+
+  # Fill in every optional field too
+  gqlx example CreateUserInput --all
+
+  # Generate the variables of an operation
+  gqlx example query.graphql --operation GetUser > vars.json
+  gqlx mock run query.graphql --operation GetUser --variables vars.json
+
+  # Use realistic custom scalars and a different seed
+  gqlx example CreateUserInput --scalars scalars.yaml --seed 7`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExample(cmd, args[0], opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include optional fields and variables, not just required ones")
+	cmd.Flags().Int64Var(&opts.seed, "seed", 0, "Seed for generated values; the same seed always produces the same example")
+	cmd.Flags().IntVar(&opts.listLength, "list-length", 1, "Number of items generated for lists")
+	cmd.Flags().StringVar(&opts.scalars, "scalars", "", "JSON or YAML file configuring value generators for scalars")
+	cmd.Flags().StringVar(&opts.operation, "operation", "", "Name of the operation to generate variables for")
+
+	return cmd
+}
+
+// isOperationPath reports whether the example argument names an operation
+// document rather than a type.
+func isOperationPath(arg string) bool {
+	switch filepath.Ext(arg) {
+	case ".graphql", ".gql":
+		return true
+	}
+	_, err := os.Stat(arg)
+	return err == nil
+}
+
+func runExample(cmd *cobra.Command, arg string, opts *exampleOptions) error {
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	g, err := newMockGenerator(schema, &mockOptions{seed: opts.seed, listLength: opts.listLength, scalars: opts.scalars})
+	if err != nil {
+		return err
+	}
+	e := &exampleGenerator{mockGenerator: g, all: opts.all}
+
+	var op *ast.OperationDefinition
+	var value any
+	var variable string
+	if schema.Types[arg] == nil && isOperationPath(arg) {
+		doc, err := loadOperationFile(cmd, schema, arg)
+		if err != nil {
+			return err
+		}
+		op, err = selectOperation(doc, opts.operation)
+		if err != nil {
+			return err
+		}
+		value, err = e.variables(op)
+		if err != nil {
+			return err
+		}
+	} else {
+		if opts.operation != "" {
+			return fmt.Errorf("--operation can only be used with an operation file")
+		}
+		if err := validateTypeExists(schema, arg, "input type"); err != nil {
+			return err
+		}
+		def := schema.Types[arg]
+		if def.Kind != ast.InputObject {
+			return fmt.Errorf("'%s' is not an input object (it's a %s)", arg, kindToString(string(def.Kind)))
+		}
+		value, err = e.inputObject(def, def.Name, map[string]bool{})
+		if err != nil {
+			return err
+		}
+
+		// Validate the input as the only variable of an operation
+		variable = "input"
+		op = &ast.OperationDefinition{
+			Operation: ast.Query,
+			VariableDefinitions: ast.VariableDefinitionList{{
+				Variable:   variable,
+				Type:       ast.NonNullNamedType(def.Name, nil),
+				Definition: def,
+			}},
+		}
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := validateExample(schema, op, variable, output); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	return nil
+}
+
+// validateExample checks a generated example the way gqlx mock run checks
+// --variables: decoded from JSON and coerced against the operation's variable
+// definitions. If variable is set, the example is the value of that variable.
+func validateExample(schema *ast.Schema, op *ast.OperationDefinition, variable string, output []byte) error {
+	var decoded any
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		return err
+	}
+	variables, _ := decoded.(map[string]any)
+	if variable != "" {
+		variables = map[string]any{variable: decoded}
+	}
+	if _, err := validator.VariableValues(schema, op, variables); err != nil {
+		return fmt.Errorf("generated example is not valid: %w", err)
+	}
+	return nil
+}
+
+// exampleGenerator generates input values from the mock generator's seed and
+// scalar generators.
+type exampleGenerator struct {
+	*mockGenerator
+	all bool
+}
+
+// isRequiredInput reports whether an input field or variable must be provided.
+func isRequiredInput(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+// variables generates the variables object for an operation.
+func (e *exampleGenerator) variables(op *ast.OperationDefinition) (*orderedObject, error) {
+	name := op.Name
+	if name == "" {
+		name = "variables"
+	}
+
+	result := newOrderedObject()
+	for _, v := range op.VariableDefinitions {
+		if !e.all && !isRequiredInput(v.Type, v.DefaultValue) {
+			continue
+		}
+		value, err := e.value(v.Type, v.Variable, name, name+"."+v.Variable, map[string]bool{})
+		if err != nil {
+			return nil, err
+		}
+		result.Set(v.Variable, value)
+	}
+	return result, nil
+}
+
+// inputObject generates an object for an input type. ancestors are the input
+// types already being generated on the way here, which a field may not repeat
+// unless the recursion can stop.
+func (e *exampleGenerator) inputObject(def *ast.Definition, path string, ancestors map[string]bool) (*orderedObject, error) {
+	ancestors[def.Name] = true
+	defer delete(ancestors, def.Name)
+
+	result := newOrderedObject()
+	oneOf := def.Directives.ForName("oneOf") != nil
+	for _, field := range def.Fields {
+		if !oneOf && !e.all && !isRequiredInput(field.Type, field.DefaultValue) {
+			continue
+		}
+
+		if ancestors[field.Type.Name()] {
+			switch {
+			case oneOf || !field.Type.NonNull:
+				continue
+			case field.Type.Elem != nil:
+				result.Set(field.Name, []any{})
+				continue
+			default:
+				return nil, fmt.Errorf("cannot generate an example at '%s': required field '%s.%s' repeats its type forever", path, def.Name, field.Name)
+			}
+		}
+
+		value, err := e.value(field.Type, field.Name, def.Name, path+"."+field.Name, ancestors)
+		if err != nil {
+			return nil, err
+		}
+		result.Set(field.Name, value)
+
+		// A @oneOf input takes exactly one field
+		if oneOf {
+			break
+		}
+	}
+	return result, nil
+}
+
+// value generates a value of an input type for a field of typeName.
+func (e *exampleGenerator) value(t *ast.Type, fieldName, typeName, path string, ancestors map[string]bool) (any, error) {
+	if t.Elem != nil {
+		items := make([]any, e.listLength)
+		for i := range items {
+			item, err := e.value(t.Elem, fieldName, typeName, path+"."+strconv.Itoa(i), ancestors)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+
+	def := e.schema.Types[t.Name()]
+	if def.Kind == ast.InputObject {
+		return e.inputObject(def, path, ancestors)
+	}
+	return e.leafValue(def, fieldName, typeName, path), nil
+}
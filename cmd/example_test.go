@@ -0,0 +1,171 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exampleTestSchema = `
+type Query {
+	user(id: ID!): User
+}
+
+type Mutation {
+	createUser(input: CreateUserInput!, dryRun: Boolean): User
+}
+
+type User {
+	id: ID!
+}
+
+scalar DateTime
+
+enum Role {
+	ADMIN
+	MEMBER @deprecated
+	GUEST
+}
+
+input CreateUserInput {
+	name: String!
+	age: Int
+	role: Role!
+	tags: [String!]!
+	birthday: DateTime
+	limit: Int! = 10
+	address: AddressInput
+	manager: CreateUserInput
+	reports: [CreateUserInput!]!
+	filter: FilterInput
+}
+
+input AddressInput {
+	street: String!
+	zip: ID
+}
+
+input FilterInput @oneOf {
+	byId: ID
+	byName: String
+}
+`
+
+func decodeExample(t *testing.T, args ...string) map[string]any {
+	t.Helper()
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"example"}, args...))
+	require.NoError(t, err)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	return result
+}
+
+func TestExample_RequiredFieldsOnly(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+
+	result := decodeExample(t, "CreateUserInput", "-s", schemaPath)
+	assert.ElementsMatch(t, []string{"name", "role", "tags", "reports"}, slices.Collect(maps.Keys(result)))
+	assert.Contains(t, []any{"ADMIN", "GUEST"}, result["role"])
+	assert.Len(t, result["tags"], 1)
+	// Recursion through a required list stops at an empty list
+	assert.Equal(t, []any{}, result["reports"])
+}
+
+func TestExample_AllFields(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"example", "CreateUserInput", "--all", "-s", schemaPath})
+	require.NoError(t, err)
+	// Keys follow the order of the input type's fields
+	assert.Regexp(t, `(?s)"name".*"age".*"role".*"tags".*"birthday".*"limit".*"address".*"reports".*"filter"`, stdout)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.NotContains(t, result, "manager", "nullable recursive fields are left out")
+	assert.IsType(t, float64(0), result["age"])
+	assert.Regexp(t, `^DateTime-\d+$`, result["birthday"])
+	assert.ElementsMatch(t, []string{"street", "zip"}, slices.Collect(maps.Keys(result["address"].(map[string]any))))
+	assert.Len(t, result["filter"], 1, "@oneOf inputs get exactly one field")
+}
+
+func TestExample_Seed(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+
+	first, _, err := cmd.ExecuteWithArgs([]string{"example", "CreateUserInput", "--all", "--seed", "7", "-s", schemaPath})
+	require.NoError(t, err)
+	second, _, err := cmd.ExecuteWithArgs([]string{"example", "CreateUserInput", "--all", "--seed", "7", "-s", schemaPath})
+	require.NoError(t, err)
+	other, _, err := cmd.ExecuteWithArgs([]string{"example", "CreateUserInput", "--all", "--seed", "8", "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, other)
+}
+
+func TestExample_Scalars(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+	scalarsPath := filepath.Join(filepath.Dir(schemaPath), "scalars.yaml")
+	require.NoError(t, os.WriteFile(scalarsPath, []byte("DateTime: {generator: date}\n"), 0644))
+
+	result := decodeExample(t, "CreateUserInput", "--all", "--scalars", scalarsPath, "-s", schemaPath)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, result["birthday"])
+}
+
+func TestExample_OperationVariables(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+	dir := filepath.Dir(schemaPath)
+	queryPath := filepath.Join(dir, "operations.graphql")
+	require.NoError(t, os.WriteFile(queryPath, []byte(`
+query GetUser($id: ID!, $fallback: ID = "1") { user(id: $id) { id } other: user(id: $fallback) { id } }
+mutation CreateUser($input: CreateUserInput!, $dryRun: Boolean) { createUser(input: $input, dryRun: $dryRun) { id } }
+`), 0644))
+
+	result := decodeExample(t, queryPath, "--operation", "GetUser", "-s", schemaPath)
+	assert.Equal(t, []string{"id"}, slices.Collect(maps.Keys(result)))
+
+	result = decodeExample(t, queryPath, "--operation", "CreateUser", "--all", "-s", schemaPath)
+	assert.ElementsMatch(t, []string{"input", "dryRun"}, slices.Collect(maps.Keys(result)))
+
+	// The example is accepted by mock run
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"example", queryPath, "--operation", "CreateUser", "-s", schemaPath})
+	require.NoError(t, err)
+	varsPath := filepath.Join(dir, "vars.json")
+	require.NoError(t, os.WriteFile(varsPath, []byte(stdout), 0644))
+	_, _, err = cmd.ExecuteWithArgs([]string{"mock", "run", queryPath, "--operation", "CreateUser", "--variables", varsPath, "-s", schemaPath})
+	require.NoError(t, err)
+}
+
+func TestExample_Errors(t *testing.T) {
+	schemaPath := writeTestSchema(t, exampleTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"example", "CreateUserInpt", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "input type 'CreateUserInpt' does not exist in schema, did you mean 'CreateUserInput'?")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"example", "Role", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'Role' is not an input object")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"example", "CreateUserInput", "--operation", "GetUser", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--operation can only be used with an operation file")
+}
+
+func TestExample_RequiredCycle(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+type Query { ok: Boolean }
+input Node { next: Node! }
+`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"example", "Node", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "required field 'Node.next' repeats its type forever")
+}
@@ -0,0 +1,195 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Apollo Federation
+//
+// Subgraph SDL uses directives (@key, @external, ...) and types (_Any,
+// _FieldSet, ...) that the federation gateway and subgraph libraries define,
+// so it does not load on its own. In federation mode the definitions are added
+// from a built-in source, like gqlparser's prelude, so they are hidden from
+// listings and printing. Anything the subgraph already declares itself is kept.
+
+// federationDefinition is a type or directive definition added in federation
+// mode.
+type federationDefinition struct {
+	name      string
+	directive bool
+	sdl       string
+}
+
+var federationV1Definitions = []federationDefinition{
+	{"_Any", false, "scalar _Any"},
+	{"_FieldSet", false, "scalar _FieldSet"},
+	{"_Service", false, "type _Service { sdl: String }"},
+	{"key", true, "directive @key(fields: _FieldSet!) repeatable on OBJECT | INTERFACE"},
+	{"external", true, "directive @external on FIELD_DEFINITION"},
+	{"requires", true, "directive @requires(fields: _FieldSet!) on FIELD_DEFINITION"},
+	{"provides", true, "directive @provides(fields: _FieldSet!) on FIELD_DEFINITION"},
+	{"extends", true, "directive @extends on OBJECT | INTERFACE"},
+}
+
+// federationV2Types are the types used by the federation v2 directives.
+var federationV2Types = []federationDefinition{
+	{"_Any", false, "scalar _Any"},
+	{"_FieldSet", false, "scalar _FieldSet"},
+	{"FieldSet", false, "scalar FieldSet"},
+	{"federation__FieldSet", false, "scalar federation__FieldSet"},
+	{"federation__Scope", false, "scalar federation__Scope"},
+	{"federation__Policy", false, "scalar federation__Policy"},
+	{"federation__ContextFieldValue", false, "scalar federation__ContextFieldValue"},
+	{"link__Import", false, "scalar link__Import"},
+	{"link__Purpose", false, "enum link__Purpose { SECURITY EXECUTION }"},
+	{"_Service", false, "type _Service { sdl: String }"},
+	{"link", true, "directive @link(url: String!, as: String, for: link__Purpose, import: [link__Import]) repeatable on SCHEMA"},
+}
+
+// federationV2Directives are the federation v2 directives. Each is defined
+// both under its own name, for use with @link(import: [...]), and with the
+// federation__ prefix used when it is not imported.
+var federationV2Directives = []federationDefinition{
+	{"key", true, "(fields: FieldSet!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE"},
+	{"requires", true, "(fields: FieldSet!) on FIELD_DEFINITION"},
+	{"provides", true, "(fields: FieldSet!) on FIELD_DEFINITION"},
+	{"external", true, "(reason: String) on OBJECT | FIELD_DEFINITION"},
+	{"extends", true, " on OBJECT | INTERFACE"},
+	{"shareable", true, " repeatable on OBJECT | FIELD_DEFINITION"},
+	{"inaccessible", true, " on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION"},
+	{"tag", true, "(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION | SCHEMA"},
+	{"override", true, "(from: String!, label: String) on FIELD_DEFINITION"},
+	{"composeDirective", true, "(name: String!) repeatable on SCHEMA"},
+	{"interfaceObject", true, " on OBJECT"},
+	{"authenticated", true, " on FIELD_DEFINITION | OBJECT | INTERFACE | SCALAR | ENUM"},
+	{"requiresScopes", true, "(scopes: [[federation__Scope!]!]!) on FIELD_DEFINITION | OBJECT | INTERFACE | SCALAR | ENUM"},
+	{"policy", true, "(policies: [[federation__Policy!]!]!) on FIELD_DEFINITION | OBJECT | INTERFACE | SCALAR | ENUM"},
+	{"context", true, "(name: String!) repeatable on INTERFACE | OBJECT | UNION"},
+	{"fromContext", true, "(field: federation__ContextFieldValue) on ARGUMENT_DEFINITION"},
+	{"cost", true, "(weight: Int!) on ARGUMENT_DEFINITION | ENUM | FIELD_DEFINITION | INPUT_FIELD_DEFINITION | OBJECT | SCALAR"},
+	{"listSize", true, "(assumedSize: Int, slicingArguments: [String!], sizedFields: [String!], requireOneSlicingArgument: Boolean = true) on FIELD_DEFINITION"},
+}
+
+// federationDefinitions returns the definitions for a federation version.
+func federationDefinitions(version int) []federationDefinition {
+	if version == 1 {
+		return federationV1Definitions
+	}
+
+	defs := slices.Clone(federationV2Types)
+	for _, d := range federationV2Directives {
+		for _, name := range []string{d.name, "federation__" + d.name} {
+			defs = append(defs, federationDefinition{name, true, "directive @" + name + d.sdl})
+		}
+	}
+	return defs
+}
+
+// federationVersion returns 2 if the subgraph links the federation v2 spec
+// with @link, and 1 otherwise.
+func federationVersion(doc *ast.SchemaDocument) int {
+	var directives ast.DirectiveList
+	for _, schema := range doc.Schema {
+		directives = append(directives, schema.Directives...)
+	}
+	for _, schema := range doc.SchemaExtension {
+		directives = append(directives, schema.Directives...)
+	}
+
+	for _, link := range directives.ForNames("link") {
+		if url := link.Arguments.ForName("url"); url != nil && strings.Contains(url.Value.Raw, "specs.apollo.dev/federation/v2") {
+			return 2
+		}
+	}
+	return 1
+}
+
+// isKeyDirective reports whether a directive is @key, imported or prefixed.
+func isKeyDirective(directive *ast.Directive) bool {
+	return directive.Name == "key" || directive.Name == "federation__key"
+}
+
+// federationEntities returns the sorted names of the object types with a @key,
+// which make up the _Entity union.
+func federationEntities(doc *ast.SchemaDocument) []string {
+	var names []string
+	for _, def := range append(slices.Clone(doc.Definitions), doc.Extensions...) {
+		if def.Kind != ast.Object || slices.Contains(names, def.Name) {
+			continue
+		}
+		if slices.ContainsFunc(def.Directives, isKeyDirective) {
+			names = append(names, def.Name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// federationQueryType returns the name of the subgraph's query root type.
+func federationQueryType(doc *ast.SchemaDocument) string {
+	for _, schema := range append(slices.Clone(doc.Schema), doc.SchemaExtension...) {
+		for _, op := range schema.OperationTypes {
+			if op.Operation == ast.Query {
+				return op.Type
+			}
+		}
+	}
+	return "Query"
+}
+
+// federationSource builds the built-in source with the federation definitions
+// a subgraph is missing: the directives and scalars for its federation
+// version, the _Entity union of its entities, and the _entities and _service
+// query fields.
+func federationSource(doc *ast.SchemaDocument) *ast.Source {
+	types := map[string]bool{}
+	queryFields := map[string]bool{}
+	queryType := federationQueryType(doc)
+	for _, def := range append(slices.Clone(doc.Definitions), doc.Extensions...) {
+		types[def.Name] = true
+		if def.Name == queryType {
+			for _, field := range def.Fields {
+				queryFields[field.Name] = true
+			}
+		}
+	}
+	directives := map[string]bool{}
+	for _, directive := range doc.Directives {
+		directives[directive.Name] = true
+	}
+
+	var lines []string
+	for _, def := range federationDefinitions(federationVersion(doc)) {
+		if (def.directive && !directives[def.name]) || (!def.directive && !types[def.name]) {
+			lines = append(lines, def.sdl)
+		}
+	}
+
+	entities := federationEntities(doc)
+	if len(entities) > 0 && !types["_Entity"] {
+		lines = append(lines, "union _Entity = "+strings.Join(entities, " | "))
+	}
+
+	var fields []string
+	if len(entities) > 0 && !queryFields["_entities"] {
+		fields = append(fields, "_entities(representations: [_Any!]!): [_Entity]!")
+	}
+	if !queryFields["_service"] {
+		fields = append(fields, "_service: _Service!")
+	}
+	if len(fields) > 0 {
+		lines = append(lines, "extend type "+queryType+" { "+strings.Join(fields, " ")+" }")
+	}
+
+	return &ast.Source{
+		Name:    "federation.graphql",
+		Input:   strings.Join(lines, "\n"),
+		BuiltIn: true,
+	}
+}
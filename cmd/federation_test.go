@@ -0,0 +1,148 @@
+package cmd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const federationV1Subgraph = `
+extend type Query {
+	topProducts(first: Int = 5): [Product]
+}
+
+type Product @key(fields: "upc") {
+	upc: String!
+	name: String
+	reviews: [Review] @requires(fields: "name")
+}
+
+extend type User @key(fields: "id") {
+	id: ID! @external
+	reviews: [Review]
+}
+
+type Review {
+	body: String
+	author: User @provides(fields: "username")
+}
+`
+
+const federationV2Subgraph = `
+extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", import: ["@key", "@shareable"])
+
+type Query {
+	me: User
+}
+
+type User @key(fields: "id") @key(fields: "email", resolvable: false) {
+	id: ID!
+	email: String! @shareable
+	legacy: String @federation__inaccessible
+	internal: String @federation__tag(name: "private")
+}
+`
+
+func TestFederation_SubgraphFailsWithoutFlag(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV1Subgraph)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"types", "-s", schemaPath, "-f", "text"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Undefined directive")
+}
+
+func TestFederation_LoadsV1Subgraph(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV1Subgraph)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-s", schemaPath, "--federation", "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "topProducts(first: Int): [Product]")
+	assert.Contains(t, stdout, "_entities(representations: [_Any!]!): [_Entity]!")
+	assert.Contains(t, stdout, "_service: _Service!")
+}
+
+func TestFederation_LoadsV2Subgraph(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV2Subgraph)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "User", "-s", schemaPath, "--federation", "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "email: String!")
+	assert.Contains(t, stdout, "legacy: String")
+}
+
+func TestFederation_V1DoesNotDefineV2Directives(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		type Query {
+			me: String @shareable
+		}
+	`)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"types", "-s", schemaPath, "--federation", "-f", "text"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Undefined directive shareable")
+}
+
+func TestFederation_DefinitionsAreHiddenFromPrint(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, federationV2Subgraph)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--federation"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "type User @key(fields: \"id\")")
+	assert.NotContains(t, stdout, "directive @key")
+	assert.NotContains(t, stdout, "scalar _Any")
+	assert.NotContains(t, stdout, "union _Entity")
+
+	// The printed subgraph loads again in federation mode
+	printedPath := setupRefsTestSchema(t, stdout)
+	_, _, err = cmd.ExecuteWithArgs([]string{"types", "-s", printedPath, "--federation", "-f", "text"})
+	require.NoError(t, err)
+}
+
+func TestFederation_KeepsDefinitionsDeclaredBySubgraph(t *testing.T) {
+	// Subgraphs generated by server libraries often declare the federation
+	// definitions themselves
+	schemaPath := setupRefsTestSchema(t, `
+		scalar _FieldSet
+		scalar _Any
+		directive @key(fields: _FieldSet!) repeatable on OBJECT | INTERFACE
+		directive @external on FIELD_DEFINITION
+
+		union _Entity = User
+
+		type _Service {
+			sdl: String
+		}
+
+		type User @key(fields: "id") {
+			id: ID!
+		}
+
+		type Query {
+			user: User
+			_entities(representations: [_Any!]!): [_Entity]!
+			_service: _Service!
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-s", schemaPath, "--federation", "-f", "text"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stdout, "schema.graphql:18: user: User\nschema.graphql:19: _entities(representations: [_Any!]!): [_Entity]!\nschema.graphql:20: _service: _Service!\n-: __schema"), stdout)
+}
+
+func TestFederation_NoEntitiesFieldWithoutEntities(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		type Query {
+			version: String
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-s", schemaPath, "--federation", "-f", "text"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stdout, "schema.graphql:3: version: String\n-: _service: _Service!\n-: __schema"), stdout)
+}
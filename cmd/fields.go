@@ -46,6 +46,7 @@ func fieldToInfo(fieldDef *ast.FieldDefinition) FieldInfo {
 		Type:         typeToString(fieldDef.Type),
 		DefaultValue: defaultValue,
 		Description:  fieldDef.Description,
+		Location:     sourceLocation(fieldDef.Position),
 	}
 }
 
@@ -82,7 +83,7 @@ func formatFieldText(field FieldInfo) string {
 	if field.Description != "" {
 		desc = " # " + strings.ReplaceAll(field.Description, "\n", " ")
 	}
-	return fmt.Sprintf("%s: %s%s", name, typeStr, desc)
+	return fmt.Sprintf("%s%s: %s%s", locationPrefix(field.Location), name, typeStr, desc)
 }
 
 func formatFieldsPretty(fields []FieldInfo) string {
@@ -95,9 +96,9 @@ func formatFieldsPretty(fields []FieldInfo) string {
 			typeStr += " = " + field.DefaultValue
 		}
 		desc := strings.ReplaceAll(field.Description, "\n", " ")
-		t.Row(name, typeStr, desc)
+		t.Row(name, typeStr, desc, formatLocation(field.Location))
 	}
-	t.Headers("field", "type", "description")
+	t.Headers("field", "type", "description", "location")
 
 	return t.String()
 }
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLocation_Hyperlink(t *testing.T) {
+	dir := t.TempDir()
+	schemaFilePath = filepath.Join(dir, "schema.graphql")
+	hyperlinks = true
+	t.Cleanup(func() { schemaFilePath, hyperlinks = "schema.graphql", false })
+
+	host, _ := os.Hostname()
+	loc := &SourceLocation{File: "schema.graphql", Line: 7, Column: 2}
+	want := "\x1b]8;;file://" + host + filepath.ToSlash(schemaFilePath) + "\x1b\\schema.graphql:7\x1b]8;;\x1b\\"
+	assert.Equal(t, want, formatLocation(loc))
+	assert.Equal(t, want+": ", locationPrefix(loc))
+	assert.Equal(t, "-: ", locationPrefix(nil))
+}
+
+func TestSourceFilePath_ConfigSchemas(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a", "schema.graphql"), filepath.Join(dir, "b", "schema.graphql")
+	activeProject, configSchemaPaths = &project{dir: dir}, []string{a, b}
+	t.Cleanup(func() { activeProject, configSchemaPaths = nil, nil })
+
+	name := filepath.Join("b", "schema.graphql")
+	assert.Equal(t, name, sourceName(b))
+	assert.Equal(t, b, sourceFilePath(name, schemaPaths()))
+}
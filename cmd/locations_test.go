@@ -0,0 +1,129 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+func setupLocationsProject(t *testing.T) {
+	setupConfigDir(t, map[string]string{
+		"graphql.config.yml": "schema: [user.graphql, post.graphql]\n",
+		"user.graphql": `type Query {
+  user(id: ID!): User
+}
+
+type User {
+  id: ID!
+  role: Role
+}
+
+enum Role {
+  ADMIN
+  MEMBER
+}
+`,
+		"post.graphql": `extend type Query {
+  posts: [Post]
+}
+
+type Post {
+  author: User
+}
+`,
+	})
+}
+
+func TestLocations_JSON(t *testing.T) {
+	setupLocationsProject(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"types", "-f", "json"})
+	require.NoError(t, err)
+	var types []struct {
+		Name     string        `json:"name"`
+		Location *testLocation `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &types))
+	locations := map[string]*testLocation{}
+	for _, typ := range types {
+		locations[typ.Name] = typ.Location
+	}
+	assert.Equal(t, &testLocation{File: "user.graphql", Line: 5, Column: 6}, locations["User"])
+	assert.Equal(t, &testLocation{File: "post.graphql", Line: 5, Column: 6}, locations["Post"])
+	assert.Nil(t, locations["String"], "built-in types have no location")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"fields", "Query", "-f", "json"})
+	require.NoError(t, err)
+	var fields []struct {
+		Name     string        `json:"name"`
+		Location *testLocation `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &fields))
+	require.GreaterOrEqual(t, len(fields), 2)
+	assert.Equal(t, &testLocation{File: "user.graphql", Line: 2, Column: 3}, fields[0].Location)
+	assert.Equal(t, &testLocation{File: "post.graphql", Line: 2, Column: 3}, fields[1].Location)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"args", "Query.user", "-f", "json"})
+	require.NoError(t, err)
+	var args []struct {
+		Location *testLocation `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &args))
+	require.Len(t, args, 1)
+	assert.Equal(t, &testLocation{File: "user.graphql", Line: 2, Column: 8}, args[0].Location)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"values", "Role", "-f", "json"})
+	require.NoError(t, err)
+	var values []struct {
+		Location *testLocation `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &values))
+	require.Len(t, values, 2)
+	assert.Equal(t, &testLocation{File: "user.graphql", Line: 12, Column: 3}, values[1].Location)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"references", "User", "-f", "json"})
+	require.NoError(t, err)
+	var refs []struct {
+		Location string        `json:"location"`
+		Source   *testLocation `json:"source"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &refs))
+	sources := map[string]*testLocation{}
+	for _, ref := range refs {
+		sources[ref.Location] = ref.Source
+	}
+	assert.Equal(t, &testLocation{File: "post.graphql", Line: 6, Column: 3}, sources["Post.author"])
+	assert.Equal(t, &testLocation{File: "user.graphql", Line: 2, Column: 3}, sources["Query.user"])
+}
+
+func TestLocations_Text(t *testing.T) {
+	setupLocationsProject(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"fields", "Query", "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "user.graphql:2: user(id: ID!): User\npost.graphql:2: posts: [Post]\n")
+	assert.Contains(t, stdout, "\n-: __schema: __Schema!\n", "built-in fields have a placeholder location")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"values", "Role", "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "user.graphql:11: ADMIN\nuser.graphql:12: MEMBER\n", stdout)
+}
+
+func TestLocations_Pretty(t *testing.T) {
+	setupLocationsProject(t)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"types", "-f", "pretty"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "location")
+	assert.Contains(t, stdout, "post.graphql:5")
+	assert.NotContains(t, stdout, "\x1b]8;;", "output that is not a terminal has no hyperlinks")
+}
@@ -0,0 +1,472 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Language Server
+//
+// gqlx lsp speaks the Language Server Protocol over stdio: JSON-RPC messages
+// framed with a Content-Length header. Documents are synced in full on every
+// change; each change republishes diagnostics from validateQuery, so editors
+// see exactly what gqlx validate reports.
+
+// JSON-RPC error codes used by the server.
+const (
+	lspParseError     = -32700
+	lspInvalidParams  = -32602
+	lspMethodNotFound = -32601
+)
+
+// LSP enum values used by the server.
+const (
+	lspSeverityError = 1
+
+	lspCompletionField    = 5
+	lspCompletionClass    = 7
+	lspCompletionProperty = 10
+	lspCompletionKeyword  = 14
+	lspCompletionEnum     = 20
+	lspCompletionFunction = 3
+
+	lspTagDeprecated = 1
+
+	lspSyncFull = 1
+)
+
+type lspRequest struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+type lspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  any              `json:"result"`
+}
+
+type lspErrorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   *lspError        `json:"error"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type lspNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type lspDocumentParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspPositionParams struct {
+	TextDocument lspTextDocument `json:"textDocument"`
+	Position     lspPosition     `json:"position"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspMarkup struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspCompletionItem struct {
+	Label         string     `json:"label"`
+	Kind          int        `json:"kind"`
+	Detail        string     `json:"detail,omitempty"`
+	Documentation *lspMarkup `json:"documentation,omitempty"`
+	Tags          []int      `json:"tags,omitempty"`
+	InsertText    string     `json:"insertText,omitempty"`
+}
+
+type lspHover struct {
+	Contents lspMarkup `json:"contents"`
+	Range    *lspRange `json:"range,omitempty"`
+}
+
+// readLSPMessage reads the body of the next Content-Length framed message.
+func readLSPMessage(r *bufio.Reader) ([]byte, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header: %w", err)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("message is missing a Content-Length header")
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// writeLSPMessage writes a Content-Length framed message.
+func writeLSPMessage(w io.Writer, message any) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+// pathToURI converts an absolute file path to a file:// URI.
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// uriToPath converts a file:// URI to a file path. Other URIs are returned
+// unchanged.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// lspOffset converts an LSP position, counted in UTF-16 code units, to a byte
+// offset in text.
+func lspOffset(text string, pos lspPosition) int {
+	offset := 0
+	for range pos.Line {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+
+	units := 0
+	for i, r := range text[offset:] {
+		if units >= pos.Character || r == '\n' {
+			return offset + i
+		}
+		units += utf16.RuneLen(r)
+	}
+	return len(text)
+}
+
+// lspPositionAt converts a byte offset in text to an LSP position.
+func lspPositionAt(text string, offset int) lspPosition {
+	pos := lspPosition{}
+	for _, r := range text[:offset] {
+		if r == '\n' {
+			pos.Line++
+			pos.Character = 0
+		} else {
+			pos.Character += utf16.RuneLen(r)
+		}
+	}
+	return pos
+}
+
+// lspServer holds the schema and the open documents.
+type lspServer struct {
+	schema      *ast.Schema
+	schemaPaths []string
+	documents   map[string]string
+	out         io.Writer
+	shutdown    bool
+}
+
+func newLSPServer(schema *ast.Schema, paths []string, out io.Writer) *lspServer {
+	return &lspServer{
+		schema:      schema,
+		schemaPaths: paths,
+		documents:   map[string]string{},
+		out:         out,
+	}
+}
+
+// serve handles messages until the client sends exit or closes the input.
+func (s *lspServer) serve(in io.Reader) error {
+	reader := bufio.NewReader(in)
+	for {
+		body, err := readLSPMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var req lspRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := writeLSPMessage(s.out, lspErrorResponse{JSONRPC: "2.0", Error: &lspError{Code: lspParseError, Message: err.Error()}}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("received exit before shutdown")
+			}
+			return nil
+		}
+
+		result, rpcErr := s.handle(req.Method, req.Params)
+		if req.ID == nil {
+			continue
+		}
+		if rpcErr != nil {
+			err = writeLSPMessage(s.out, lspErrorResponse{JSONRPC: "2.0", ID: req.ID, Error: rpcErr})
+		} else {
+			err = writeLSPMessage(s.out, lspResponse{JSONRPC: "2.0", ID: req.ID, Result: result})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches a request or notification to its handler.
+func (s *lspServer) handle(method string, params json.RawMessage) (any, *lspError) {
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					"change":    lspSyncFull,
+					"save":      true,
+				},
+				"completionProvider": map[string]any{
+					"triggerCharacters": []string{"{", "(", ":", "@", "."},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]any{"name": "gqlx"},
+		}, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen", "textDocument/didChange", "textDocument/didSave", "textDocument/didClose":
+		var p lspDocumentParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		s.syncDocument(method, p)
+		return nil, nil
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var p lspPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		text, ok := s.documents[p.TextDocument.URI]
+		if !ok || s.isSchemaDocument(p.TextDocument.URI) {
+			return nil, nil
+		}
+		offset := lspOffset(text, p.Position)
+		switch method {
+		case "textDocument/completion":
+			return s.completion(text, offset), nil
+		case "textDocument/hover":
+			return s.hover(text, offset), nil
+		default:
+			return s.definition(p.TextDocument.URI, text, offset), nil
+		}
+	case "initialized", "$/cancelRequest", "$/setTrace", "workspace/didChangeConfiguration":
+		return nil, nil
+	default:
+		return nil, &lspError{Code: lspMethodNotFound, Message: fmt.Sprintf("method '%s' is not supported", method)}
+	}
+}
+
+// isSchemaDocument reports whether the document is one of the schema files,
+// which are not validated as operation documents.
+func (s *lspServer) isSchemaDocument(uri string) bool {
+	return slices.Contains(s.schemaPaths, uriToPath(uri))
+}
+
+// syncDocument applies a document notification and republishes diagnostics.
+// Saving a schema file reloads the schema and revalidates every document.
+func (s *lspServer) syncDocument(method string, p lspDocumentParams) {
+	uri := p.TextDocument.URI
+
+	switch method {
+	case "textDocument/didOpen":
+		s.documents[uri] = p.TextDocument.Text
+	case "textDocument/didChange":
+		if len(p.ContentChanges) > 0 {
+			s.documents[uri] = p.ContentChanges[len(p.ContentChanges)-1].Text
+		}
+	case "textDocument/didClose":
+		delete(s.documents, uri)
+		s.publishDiagnostics(uri, []lspDiagnostic{})
+		return
+	case "textDocument/didSave":
+		if !s.isSchemaDocument(uri) {
+			return
+		}
+		if schema, err := loadSchema(); err == nil {
+			s.schema = schema
+		}
+		for uri := range s.documents {
+			s.publishDiagnostics(uri, s.diagnostics(uri))
+		}
+		return
+	}
+
+	s.publishDiagnostics(uri, s.diagnostics(uri))
+}
+
+// diagnostics validates a document, reporting the same errors and
+// suggestions as gqlx validate.
+func (s *lspServer) diagnostics(uri string) []lspDiagnostic {
+	diagnostics := []lspDiagnostic{}
+	if s.isSchemaDocument(uri) {
+		return diagnostics
+	}
+
+	result := validateQuery(uriToPath(uri), s.documents[uri], s.schema)
+	for _, err := range result.Errors {
+		diagnostic := lspDiagnostic{
+			Severity: lspSeverityError,
+			Code:     err.Rule,
+			Source:   "gqlx",
+			Message:  err.Message,
+		}
+		if suggestion := errorSuggestion(err, s.schema); suggestion != "" {
+			diagnostic.Message += "\nhelp: " + suggestion
+		}
+		if len(err.Locations) > 0 {
+			start := lspPosition{Line: err.Locations[0].Line - 1, Character: err.Locations[0].Column - 1}
+			end := start
+			end.Character += errorSpanLength(err)
+			diagnostic.Range = lspRange{Start: start, End: end}
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics
+}
+
+func (s *lspServer) publishDiagnostics(uri string, diagnostics []lspDiagnostic) {
+	writeLSPMessage(s.out, lspNotification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params:  lspPublishDiagnosticsParams{URI: uri, Diagnostics: diagnostics},
+	})
+}
+
+func NewLSPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lsp",
+		Short: "Runs a language server for GraphQL operation documents",
+		Long: `Runs a Language Server Protocol server over stdio for .graphql operation
+documents, backed by the schema given with -s.
+
+The server provides:
+  - diagnostics with the same rules and suggestions as gqlx validate
+  - completion of fields, arguments, input fields, enum values, directives
+    and type conditions
+  - hover with signatures, descriptions and deprecation reasons
+  - go-to-definition into the schema SDL files, and to fragment definitions
+
+Saving a schema file in the editor reloads the schema and revalidates every
+open document. Schema files are not validated as operation documents.`,
+		Example: `  # Neovim (nvim-lspconfig)
+  vim.lsp.start({ name = "gqlx", cmd = { "gqlx", "lsp", "-s", "schema.graphql" } })
+
+  # Helix (languages.toml)
+  [language-server.gqlx]
+  command = "gqlx"
+  args = ["lsp", "-s", "schema.graphql"]`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			schema, err := loadCliForSchema()
+			if err != nil {
+				return err
+			}
+			var paths []string
+			for _, path := range append(schemaPaths(), subgraphPaths...) {
+				abs, err := filepath.Abs(path)
+				if err != nil {
+					return err
+				}
+				paths = append(paths, abs)
+			}
+			return newLSPServer(schema, paths, cmd.OutOrStdout()).serve(cmd.InOrStdin())
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,421 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/lexer"
+)
+
+// Cursor Context
+//
+// Completion, hover and go-to-definition need to know what a name at the
+// cursor refers to, usually while the document is being edited and does not
+// parse. Instead of parsing, the text before the cursor is tokenised and a
+// stack of open brackets is tracked: selection sets know their parent type,
+// argument lists and input objects know their input values, and list values
+// know their element type.
+
+type lspContextKind int
+
+const (
+	lspContextNone           lspContextKind = iota
+	lspContextDocument                      // operation and fragment keywords
+	lspContextField                         // field in a selection set
+	lspContextTypeCondition                 // type after "on"
+	lspContextFragmentSpread                // fragment name after "..."
+	lspContextDirective                     // directive name after "@"
+	lspContextArgument                      // argument or input object field name
+	lspContextValue                         // argument, input field or list value
+	lspContextVariableType                  // type of a variable definition
+)
+
+// lspContext describes what a name at the cursor refers to.
+type lspContext struct {
+	kind lspContextKind
+	// parent is the selection set type for fields and type conditions. It is
+	// nil for the type condition of a fragment definition.
+	parent *ast.Definition
+	// owner describes where inputs come from, e.g. "argument of `Query.user`".
+	owner  string
+	inputs ast.ArgumentDefinitionList
+	// valueType is the expected type of a value, if known.
+	valueType *ast.Type
+}
+
+type lspFrameKind int
+
+const (
+	lspFrameDocument lspFrameKind = iota
+	lspFrameSelection
+	lspFrameArguments
+	lspFrameObject
+	lspFrameList
+	lspFrameVariables
+)
+
+// lspFrame is an open bracket and the parse state inside it.
+type lspFrame struct {
+	kind lspFrameKind
+
+	// Document and selection frames
+	parent        *ast.Definition
+	field         *ast.FieldDefinition
+	pendingType   *ast.Definition // type of the next selection set
+	arguments     ast.ArgumentDefinitionList
+	argumentOwner string
+	alias         bool // seen "alias:", expecting the field name
+	spread        bool // seen "..."
+	typeCondition bool // seen "on", expecting a type
+	directive     bool // seen "@", expecting a directive name
+	fragment      int  // document: names left in "fragment Name on"
+	definition    bool // document: seen an operation or fragment keyword
+
+	// Argument, object, list and variables frames
+	owner       string
+	inputs      ast.ArgumentDefinitionList
+	valueType   *ast.Type
+	expectValue bool
+	expectType  bool
+	variable    bool // seen "$", expecting a variable name
+}
+
+type lspScanner struct {
+	schema *ast.Schema
+	stack  []*lspFrame
+}
+
+// scanLSPContext returns the context of a name starting at the end of text.
+func scanLSPContext(schema *ast.Schema, text string) lspContext {
+	s := &lspScanner{schema: schema, stack: []*lspFrame{{kind: lspFrameDocument}}}
+
+	lex := lexer.New(&ast.Source{Input: text})
+	for {
+		tok, err := lex.ReadToken()
+		if err != nil || tok.Kind == lexer.EOF {
+			break
+		}
+		if tok.Kind != lexer.Comment {
+			s.token(tok)
+		}
+	}
+
+	return s.context()
+}
+
+func (s *lspScanner) top() *lspFrame {
+	return s.stack[len(s.stack)-1]
+}
+
+func (s *lspScanner) push(frame *lspFrame) {
+	s.stack = append(s.stack, frame)
+}
+
+// pop closes the innermost bracket. A value that was being written in the
+// enclosing frame is complete.
+func (s *lspScanner) pop() {
+	if len(s.stack) > 1 {
+		s.stack = s.stack[:len(s.stack)-1]
+	}
+	if parent := s.top(); parent.kind != lspFrameList {
+		parent.expectValue = false
+	}
+}
+
+// lspFieldDefinition looks up a field on a selection set type, including the
+// __typename meta field.
+func lspFieldDefinition(parent *ast.Definition, name string) *ast.FieldDefinition {
+	if parent == nil {
+		return nil
+	}
+	if name == "__typename" {
+		return &ast.FieldDefinition{Name: name, Type: ast.NonNullNamedType("String", nil), Description: "The name of the object type."}
+	}
+	return parent.Fields.ForName(name)
+}
+
+// inputFields returns the fields of an input object as input values.
+func inputFields(def *ast.Definition) ast.ArgumentDefinitionList {
+	if def == nil || def.Kind != ast.InputObject {
+		return nil
+	}
+	var inputs ast.ArgumentDefinitionList
+	for _, field := range def.Fields {
+		inputs = append(inputs, &ast.ArgumentDefinition{
+			Name:         field.Name,
+			Description:  field.Description,
+			DefaultValue: field.DefaultValue,
+			Type:         field.Type,
+			Directives:   field.Directives,
+			Position:     field.Position,
+		})
+	}
+	return inputs
+}
+
+// pushValue opens an input object or list value of the given type.
+func (s *lspScanner) pushValue(kind lspFrameKind, t *ast.Type) {
+	switch kind {
+	case lspFrameObject:
+		var def *ast.Definition
+		if t != nil {
+			def = s.schema.Types[t.Name()]
+		}
+		frame := &lspFrame{kind: lspFrameObject, inputs: inputFields(def)}
+		if def != nil {
+			frame.owner = "field of `" + def.Name + "`"
+		}
+		s.push(frame)
+	case lspFrameList:
+		var elem *ast.Type
+		if t != nil {
+			elem = t
+			if t.Elem != nil {
+				elem = t.Elem
+			}
+		}
+		s.push(&lspFrame{kind: lspFrameList, valueType: elem, expectValue: true})
+	}
+}
+
+// directiveArguments records the arguments of a directive for a following "(".
+func (s *lspScanner) directiveArguments(frame *lspFrame, name string) {
+	frame.directive = false
+	frame.arguments = nil
+	frame.argumentOwner = "argument of `@" + name + "`"
+	if def := s.schema.Directives[name]; def != nil {
+		frame.arguments = def.Arguments
+	}
+}
+
+func (s *lspScanner) token(tok lexer.Token) {
+	frame := s.top()
+	switch frame.kind {
+	case lspFrameDocument:
+		s.documentToken(frame, tok)
+	case lspFrameSelection:
+		s.selectionToken(frame, tok)
+	case lspFrameVariables:
+		s.variablesToken(frame, tok)
+	default:
+		s.inputToken(frame, tok)
+	}
+}
+
+func (s *lspScanner) documentToken(frame *lspFrame, tok lexer.Token) {
+	switch tok.Kind {
+	case lexer.Name:
+		switch {
+		case frame.directive:
+			s.directiveArguments(frame, tok.Value)
+		case frame.typeCondition:
+			frame.typeCondition = false
+			frame.pendingType = s.schema.Types[tok.Value]
+		case frame.fragment == 2:
+			frame.fragment = 1
+		case frame.fragment == 1 && tok.Value == "on":
+			frame.fragment = 0
+			frame.typeCondition = true
+		case tok.Value == "fragment":
+			*frame = lspFrame{kind: lspFrameDocument, fragment: 2, definition: true}
+		case tok.Value == "query":
+			*frame = lspFrame{kind: lspFrameDocument, pendingType: s.schema.Query, definition: true}
+		case tok.Value == "mutation":
+			*frame = lspFrame{kind: lspFrameDocument, pendingType: s.schema.Mutation, definition: true}
+		case tok.Value == "subscription":
+			*frame = lspFrame{kind: lspFrameDocument, pendingType: s.schema.Subscription, definition: true}
+		}
+	case lexer.At:
+		frame.directive = true
+	case lexer.ParenL:
+		if frame.arguments != nil {
+			s.push(&lspFrame{kind: lspFrameArguments, inputs: frame.arguments, owner: frame.argumentOwner})
+			frame.arguments = nil
+		} else {
+			s.push(&lspFrame{kind: lspFrameVariables})
+		}
+	case lexer.BraceL:
+		parent := frame.pendingType
+		if parent == nil && !frame.definition {
+			// Shorthand query
+			parent = s.schema.Query
+		}
+		*frame = lspFrame{kind: lspFrameDocument}
+		s.push(&lspFrame{kind: lspFrameSelection, parent: parent})
+	}
+}
+
+func (s *lspScanner) selectionToken(frame *lspFrame, tok lexer.Token) {
+	switch tok.Kind {
+	case lexer.Name:
+		switch {
+		case frame.directive:
+			s.directiveArguments(frame, tok.Value)
+		case frame.typeCondition:
+			frame.typeCondition = false
+			frame.pendingType = s.schema.Types[tok.Value]
+		case frame.spread:
+			frame.spread = false
+			frame.typeCondition = tok.Value == "on"
+		default:
+			frame.alias = false
+			frame.pendingType = nil
+			frame.field = lspFieldDefinition(frame.parent, tok.Value)
+			frame.arguments = nil
+			if frame.field != nil {
+				frame.arguments = frame.field.Arguments
+				frame.argumentOwner = "argument of `" + frame.parent.Name + "." + frame.field.Name + "`"
+			}
+		}
+	case lexer.Colon:
+		frame.alias = true
+		frame.field = nil
+	case lexer.Spread:
+		frame.spread = true
+		frame.field = nil
+		frame.pendingType = nil
+	case lexer.At:
+		frame.directive = true
+	case lexer.ParenL:
+		s.push(&lspFrame{kind: lspFrameArguments, inputs: frame.arguments, owner: frame.argumentOwner})
+	case lexer.BraceL:
+		var parent *ast.Definition
+		switch {
+		case frame.pendingType != nil:
+			parent = frame.pendingType
+		case frame.spread:
+			parent = frame.parent
+		case frame.field != nil:
+			parent = s.schema.Types[frame.field.Type.Name()]
+		}
+		frame.spread = false
+		frame.field = nil
+		frame.pendingType = nil
+		s.push(&lspFrame{kind: lspFrameSelection, parent: parent})
+	case lexer.BraceR:
+		s.pop()
+	}
+}
+
+func (s *lspScanner) inputToken(frame *lspFrame, tok lexer.Token) {
+	switch tok.Kind {
+	case lexer.Name:
+		switch {
+		case frame.kind == lspFrameList:
+		case frame.variable, frame.expectValue:
+			frame.variable = false
+			frame.expectValue = false
+		default:
+			frame.valueType = nil
+			if input := frame.inputs.ForName(tok.Value); input != nil {
+				frame.valueType = input.Type
+			}
+		}
+	case lexer.Colon:
+		frame.expectValue = true
+	case lexer.Dollar:
+		frame.variable = frame.expectValue
+	case lexer.Int, lexer.Float, lexer.String, lexer.BlockString:
+		if frame.kind != lspFrameList {
+			frame.expectValue = false
+		}
+	case lexer.BraceL:
+		if frame.expectValue {
+			s.pushValue(lspFrameObject, frame.valueType)
+		}
+	case lexer.BracketL:
+		if frame.expectValue {
+			s.pushValue(lspFrameList, frame.valueType)
+		}
+	case lexer.ParenR:
+		if frame.kind == lspFrameArguments {
+			s.pop()
+		}
+	case lexer.BraceR:
+		if frame.kind == lspFrameObject {
+			s.pop()
+		}
+	case lexer.BracketR:
+		if frame.kind == lspFrameList {
+			s.pop()
+		}
+	}
+}
+
+func (s *lspScanner) variablesToken(frame *lspFrame, tok lexer.Token) {
+	switch tok.Kind {
+	case lexer.Dollar:
+		frame.variable = true
+		frame.expectValue = false
+	case lexer.Name:
+		switch {
+		case frame.variable:
+			frame.variable = false
+		case frame.expectType:
+			frame.expectType = false
+			frame.valueType = ast.NamedType(tok.Value, nil)
+		case frame.expectValue:
+			frame.expectValue = false
+		}
+	case lexer.Colon:
+		frame.expectType = true
+	case lexer.Equals:
+		frame.expectValue = true
+	case lexer.Int, lexer.Float, lexer.String, lexer.BlockString:
+		frame.expectValue = false
+	case lexer.BraceL:
+		if frame.expectValue {
+			s.pushValue(lspFrameObject, frame.valueType)
+		}
+	case lexer.BracketL:
+		if frame.expectValue {
+			s.pushValue(lspFrameList, frame.valueType)
+		}
+	case lexer.ParenR:
+		s.pop()
+	}
+}
+
+// context describes a name that would start after the last token.
+func (s *lspScanner) context() lspContext {
+	frame := s.top()
+	switch frame.kind {
+	case lspFrameDocument:
+		switch {
+		case frame.directive:
+			return lspContext{kind: lspContextDirective}
+		case frame.typeCondition:
+			return lspContext{kind: lspContextTypeCondition}
+		case frame.fragment > 0:
+			return lspContext{kind: lspContextNone}
+		}
+		return lspContext{kind: lspContextDocument}
+	case lspFrameSelection:
+		switch {
+		case frame.directive:
+			return lspContext{kind: lspContextDirective}
+		case frame.typeCondition:
+			return lspContext{kind: lspContextTypeCondition, parent: frame.parent}
+		case frame.spread:
+			return lspContext{kind: lspContextFragmentSpread, parent: frame.parent}
+		}
+		return lspContext{kind: lspContextField, parent: frame.parent}
+	case lspFrameVariables:
+		switch {
+		case frame.expectType:
+			return lspContext{kind: lspContextVariableType}
+		case frame.expectValue:
+			return lspContext{kind: lspContextValue, valueType: frame.valueType}
+		}
+		return lspContext{kind: lspContextNone}
+	default:
+		switch {
+		case frame.variable:
+			return lspContext{kind: lspContextNone}
+		case frame.expectValue:
+			return lspContext{kind: lspContextValue, valueType: frame.valueType}
+		}
+		return lspContext{kind: lspContextArgument, owner: frame.owner, inputs: frame.inputs}
+	}
+}
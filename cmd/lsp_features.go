@@ -0,0 +1,408 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// executableDirectiveLocations are the locations a directive can be used in
+// an operation document.
+var executableDirectiveLocations = []ast.DirectiveLocation{
+	ast.LocationQuery,
+	ast.LocationMutation,
+	ast.LocationSubscription,
+	ast.LocationField,
+	ast.LocationFragmentDefinition,
+	ast.LocationFragmentSpread,
+	ast.LocationInlineFragment,
+	ast.LocationVariableDefinition,
+}
+
+// fragmentDefinitionRegex finds fragment definitions in a document that may
+// not parse.
+var fragmentDefinitionRegex = regexp.MustCompile(`\bfragment\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+func isNameByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// lspWordAt returns the byte range of the name around offset.
+func lspWordAt(text string, offset int) (int, int) {
+	start, end := offset, offset
+	for start > 0 && isNameByte(text[start-1]) {
+		start--
+	}
+	for end < len(text) && isNameByte(text[end]) {
+		end++
+	}
+	return start, end
+}
+
+// lspElement is the schema element a name in a document refers to.
+type lspElement struct {
+	signature   string
+	owner       string
+	description string
+	directives  ast.DirectiveList
+	position    *ast.Position
+}
+
+// markdown renders the element for hover.
+func (e *lspElement) markdown() string {
+	parts := []string{"```graphql\n" + e.signature + "\n```"}
+	if e.owner != "" {
+		parts = append(parts, upperFirst(e.owner))
+	}
+	if e.description != "" {
+		parts = append(parts, e.description)
+	}
+	if reason, ok := deprecationReason(e.directives); ok {
+		parts = append(parts, "**Deprecated:** "+reason)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// lspDocumentation renders the documentation of a completion item.
+func lspDocumentation(description string, directives ast.DirectiveList) *lspMarkup {
+	var parts []string
+	if description != "" {
+		parts = append(parts, description)
+	}
+	if reason, ok := deprecationReason(directives); ok {
+		parts = append(parts, "**Deprecated:** "+reason)
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return &lspMarkup{Kind: "markdown", Value: strings.Join(parts, "\n\n")}
+}
+
+func lspTags(directives ast.DirectiveList) []int {
+	if _, ok := deprecationReason(directives); ok {
+		return []int{lspTagDeprecated}
+	}
+	return nil
+}
+
+func fieldSignature(parent *ast.Definition, def *ast.FieldDefinition) string {
+	info := fieldToInfo(def)
+	info.TypeName = parent.Name
+	return formatFieldName(info, render.FormatText) + ": " + info.Type
+}
+
+func inputSignature(def *ast.ArgumentDefinition) string {
+	signature := def.Name + ": " + typeToString(def.Type)
+	if def.DefaultValue != nil {
+		signature += " = " + def.DefaultValue.String()
+	}
+	return signature
+}
+
+func directiveSignature(def *ast.DirectiveDefinition) string {
+	signature := "@" + def.Name
+	if len(def.Arguments) > 0 {
+		signature += "(" + strings.Join(slices.Collect(pluck(def.Arguments, inputSignature)), ", ") + ")"
+	}
+	return signature
+}
+
+func typeSignature(def *ast.Definition) string {
+	signature := kindToString(string(def.Kind)) + " " + def.Name
+	if len(def.Interfaces) > 0 {
+		signature += " implements " + strings.Join(def.Interfaces, " & ")
+	}
+	if len(def.Types) > 0 {
+		signature += " = " + strings.Join(def.Types, " | ")
+	}
+	return signature
+}
+
+func isExecutableDirective(def *ast.DirectiveDefinition) bool {
+	return slices.ContainsFunc(def.Locations, func(location ast.DirectiveLocation) bool {
+		return slices.Contains(executableDirectiveLocations, location)
+	})
+}
+
+// typeConditionCandidates returns the composite types a fragment can be
+// spread on within a selection set of the parent type. With no parent (a
+// fragment definition), every composite type is a candidate.
+func (s *lspServer) typeConditionCandidates(parent *ast.Definition) []*ast.Definition {
+	possible := map[string]bool{}
+	if parent != nil {
+		for _, object := range concreteTypes(s.schema, parent) {
+			possible[object.Name] = true
+		}
+	}
+
+	var candidates []*ast.Definition
+	for _, name := range slices.Sorted(maps.Keys(s.schema.Types)) {
+		def := s.schema.Types[name]
+		if def.BuiltIn || (def.Kind != ast.Object && def.Kind != ast.Interface && def.Kind != ast.Union) {
+			continue
+		}
+		if parent == nil || slices.ContainsFunc(concreteTypes(s.schema, def), func(object *ast.Definition) bool { return possible[object.Name] }) {
+			candidates = append(candidates, def)
+		}
+	}
+	return candidates
+}
+
+// inputTypeCandidates returns the types a variable can have.
+func (s *lspServer) inputTypeCandidates() []*ast.Definition {
+	var candidates []*ast.Definition
+	for _, name := range slices.Sorted(maps.Keys(s.schema.Types)) {
+		def := s.schema.Types[name]
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		if def.Kind == ast.Scalar || def.Kind == ast.Enum || def.Kind == ast.InputObject {
+			candidates = append(candidates, def)
+		}
+	}
+	return candidates
+}
+
+func typeCompletionItems(defs []*ast.Definition) []lspCompletionItem {
+	var items []lspCompletionItem
+	for _, def := range defs {
+		items = append(items, lspCompletionItem{
+			Label:         def.Name,
+			Kind:          lspCompletionClass,
+			Detail:        kindToString(string(def.Kind)),
+			Documentation: lspDocumentation(def.Description, nil),
+		})
+	}
+	return items
+}
+
+// completion returns the names that can be written at the offset.
+func (s *lspServer) completion(text string, offset int) []lspCompletionItem {
+	start, _ := lspWordAt(text, offset)
+	ctx := scanLSPContext(s.schema, text[:start])
+
+	items := []lspCompletionItem{}
+	switch ctx.kind {
+	case lspContextDocument:
+		keywords := []string{"query"}
+		if s.schema.Mutation != nil {
+			keywords = append(keywords, "mutation")
+		}
+		if s.schema.Subscription != nil {
+			keywords = append(keywords, "subscription")
+		}
+		keywords = append(keywords, "fragment")
+		for _, keyword := range keywords {
+			items = append(items, lspCompletionItem{Label: keyword, Kind: lspCompletionKeyword})
+		}
+
+	case lspContextField:
+		if ctx.parent == nil {
+			break
+		}
+		for _, def := range ctx.parent.Fields {
+			if strings.HasPrefix(def.Name, "__") {
+				continue
+			}
+			items = append(items, lspCompletionItem{
+				Label:         def.Name,
+				Kind:          lspCompletionField,
+				Detail:        typeToString(def.Type),
+				Documentation: lspDocumentation(def.Description, def.Directives),
+				Tags:          lspTags(def.Directives),
+			})
+		}
+		items = append(items, lspCompletionItem{Label: "__typename", Kind: lspCompletionField, Detail: "String!"})
+
+	case lspContextTypeCondition:
+		items = append(items, typeCompletionItems(s.typeConditionCandidates(ctx.parent))...)
+
+	case lspContextFragmentSpread:
+		items = append(items, lspCompletionItem{Label: "on", Kind: lspCompletionKeyword})
+		for _, match := range fragmentDefinitionRegex.FindAllStringSubmatch(text, -1) {
+			items = append(items, lspCompletionItem{Label: match[1], Kind: lspCompletionFunction, Detail: "fragment"})
+		}
+
+	case lspContextDirective:
+		for _, name := range slices.Sorted(maps.Keys(s.schema.Directives)) {
+			def := s.schema.Directives[name]
+			if !isExecutableDirective(def) {
+				continue
+			}
+			items = append(items, lspCompletionItem{
+				Label:         def.Name,
+				Kind:          lspCompletionFunction,
+				Detail:        directiveSignature(def),
+				Documentation: lspDocumentation(def.Description, nil),
+			})
+		}
+
+	case lspContextArgument:
+		for _, def := range ctx.inputs {
+			items = append(items, lspCompletionItem{
+				Label:         def.Name,
+				Kind:          lspCompletionProperty,
+				Detail:        typeToString(def.Type),
+				Documentation: lspDocumentation(def.Description, def.Directives),
+				Tags:          lspTags(def.Directives),
+				InsertText:    def.Name + ": ",
+			})
+		}
+
+	case lspContextValue:
+		if ctx.valueType == nil {
+			break
+		}
+		def := s.schema.Types[ctx.valueType.Name()]
+		switch {
+		case def == nil:
+		case def.Kind == ast.Enum:
+			for _, value := range def.EnumValues {
+				items = append(items, lspCompletionItem{
+					Label:         value.Name,
+					Kind:          lspCompletionEnum,
+					Detail:        def.Name,
+					Documentation: lspDocumentation(value.Description, value.Directives),
+					Tags:          lspTags(value.Directives),
+				})
+			}
+		case def.Name == "Boolean":
+			for _, value := range []string{"true", "false"} {
+				items = append(items, lspCompletionItem{Label: value, Kind: lspCompletionKeyword})
+			}
+		}
+
+	case lspContextVariableType:
+		items = append(items, typeCompletionItems(s.inputTypeCandidates())...)
+	}
+
+	return items
+}
+
+// resolve returns the schema element a name refers to in the given context.
+func (s *lspServer) resolve(ctx lspContext, name string) *lspElement {
+	typeElement := func(def *ast.Definition) *lspElement {
+		if def == nil {
+			return nil
+		}
+		return &lspElement{signature: typeSignature(def), description: def.Description, directives: def.Directives, position: def.Position}
+	}
+
+	switch ctx.kind {
+	case lspContextField:
+		def := lspFieldDefinition(ctx.parent, name)
+		if def == nil {
+			return nil
+		}
+		return &lspElement{signature: fieldSignature(ctx.parent, def), description: def.Description, directives: def.Directives, position: def.Position}
+
+	case lspContextTypeCondition, lspContextVariableType:
+		return typeElement(s.schema.Types[name])
+
+	case lspContextDirective:
+		def := s.schema.Directives[name]
+		if def == nil {
+			return nil
+		}
+		return &lspElement{signature: directiveSignature(def), description: def.Description, position: def.Position}
+
+	case lspContextArgument:
+		def := ctx.inputs.ForName(name)
+		if def == nil {
+			return nil
+		}
+		return &lspElement{signature: inputSignature(def), owner: ctx.owner, description: def.Description, directives: def.Directives, position: def.Position}
+
+	case lspContextValue:
+		if ctx.valueType == nil {
+			return nil
+		}
+		def := s.schema.Types[ctx.valueType.Name()]
+		if def == nil || def.Kind != ast.Enum {
+			return nil
+		}
+		value := def.EnumValues.ForName(name)
+		if value == nil {
+			return nil
+		}
+		return &lspElement{signature: def.Name + "." + value.Name, description: value.Description, directives: value.Directives, position: value.Position}
+	}
+	return nil
+}
+
+// hover describes the schema element under the cursor.
+func (s *lspServer) hover(text string, offset int) *lspHover {
+	start, end := lspWordAt(text, offset)
+	if start == end {
+		return nil
+	}
+
+	element := s.resolve(scanLSPContext(s.schema, text[:start]), text[start:end])
+	if element == nil {
+		return nil
+	}
+
+	r := lspRange{Start: lspPositionAt(text, start), End: lspPositionAt(text, end)}
+	return &lspHover{Contents: lspMarkup{Kind: "markdown", Value: element.markdown()}, Range: &r}
+}
+
+// lspNamePosition returns the position of a schema element's name. Elements
+// with a description are positioned at the description, so the name is looked
+// for at the start of the following lines.
+func lspNamePosition(pos *ast.Position, name string) lspPosition {
+	lines := strings.Split(pos.Src.Input, "\n")
+	startsWithName := func(s string) bool {
+		return strings.HasPrefix(s, name) && (len(s) == len(name) || !isNameByte(s[len(name)]))
+	}
+
+	if pos.Line >= 1 && pos.Line <= len(lines) && pos.Column >= 1 && pos.Column <= len(lines[pos.Line-1]) {
+		if startsWithName(lines[pos.Line-1][pos.Column-1:]) {
+			return lspPosition{Line: pos.Line - 1, Character: pos.Column - 1}
+		}
+	}
+	for i := pos.Line; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " \t")
+		if startsWithName(trimmed) {
+			return lspPosition{Line: i, Character: len(lines[i]) - len(trimmed)}
+		}
+	}
+	return lspPosition{Line: pos.Line - 1, Character: pos.Column - 1}
+}
+
+// definition returns where the name under the cursor is defined: in the
+// schema file for schema elements, or in the document for fragment spreads.
+func (s *lspServer) definition(uri string, text string, offset int) *lspLocation {
+	start, end := lspWordAt(text, offset)
+	if start == end {
+		return nil
+	}
+	name := text[start:end]
+	ctx := scanLSPContext(s.schema, text[:start])
+
+	if ctx.kind == lspContextFragmentSpread {
+		for _, match := range fragmentDefinitionRegex.FindAllStringSubmatchIndex(text, -1) {
+			if text[match[2]:match[3]] == name {
+				return &lspLocation{URI: uri, Range: lspRange{Start: lspPositionAt(text, match[2]), End: lspPositionAt(text, match[3])}}
+			}
+		}
+		return nil
+	}
+
+	element := s.resolve(ctx, name)
+	if element == nil || element.position == nil || element.position.Src == nil || element.position.Src.BuiltIn {
+		return nil
+	}
+
+	pos := lspNamePosition(element.position, name)
+	return &lspLocation{
+		URI:   pathToURI(sourceFilePath(element.position.Src.Name, s.schemaPaths)),
+		Range: lspRange{Start: pos, End: lspPosition{Line: pos.Line, Character: pos.Character + len(name)}},
+	}
+}
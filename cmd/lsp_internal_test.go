@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const lspTestSchema = `type Query {
+  "Looks up a user."
+  user(id: ID!): User
+  users(filter: UserFilter, first: Int = 10): [User!]!
+  search(term: String!): [SearchResult!]!
+}
+
+"""
+A person with an account.
+"""
+type User implements Node {
+  id: ID!
+  name: String
+  role: Role!
+  legacyName: String @deprecated(reason: "Use name")
+  posts(published: Boolean): [Post!]!
+}
+
+type Post implements Node {
+  id: ID!
+  title: String!
+}
+
+interface Node {
+  id: ID!
+}
+
+union SearchResult = User | Post
+
+enum Role {
+  ADMIN
+  "A regular member."
+  MEMBER
+}
+
+input UserFilter {
+  role: Role
+  roles: [Role!]
+  nameContains: String
+}
+`
+
+const lspTestSchemaPath = "/project/schema.graphql"
+
+func newLSPTestServer(t *testing.T) (*lspServer, *bytes.Buffer) {
+	t.Helper()
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: sourceName(lspTestSchemaPath), Input: lspTestSchema})
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+	return newLSPServer(schema, []string{lspTestSchemaPath}, out), out
+}
+
+// splitCursor removes the "|" cursor marker from text and returns its offset.
+func splitCursor(t *testing.T, text string) (string, int) {
+	t.Helper()
+	offset := strings.Index(text, "|")
+	require.GreaterOrEqual(t, offset, 0, "text has no cursor marker")
+	return text[:offset] + text[offset+1:], offset
+}
+
+func completionLabels(items []lspCompletionItem) []string {
+	var labels []string
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	return labels
+}
+
+// readLSPMessages decodes every framed message written by the server.
+func readLSPMessages(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+	reader := bufio.NewReader(out)
+	var messages []map[string]any
+	for reader.Buffered() > 0 || out.Len() > 0 {
+		body, err := readLSPMessage(reader)
+		require.NoError(t, err)
+		var message map[string]any
+		require.NoError(t, json.Unmarshal(body, &message))
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func frameLSPMessages(t *testing.T, messages ...any) *bytes.Buffer {
+	t.Helper()
+	in := &bytes.Buffer{}
+	for _, message := range messages {
+		require.NoError(t, writeLSPMessage(in, message))
+	}
+	return in
+}
+
+func TestLSPCompletion(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		contains []string
+		excludes []string
+	}{
+		{"document keywords", `|`, []string{"query", "fragment"}, []string{"mutation", "subscription"}},
+		{"root fields", `query { | }`, []string{"user", "users", "search", "__typename"}, []string{"__schema", "name"}},
+		{"shorthand query", `{ us| }`, []string{"user", "users"}, nil},
+		{"nested fields", `{ user(id: "1") { | } }`, []string{"id", "name", "role", "posts"}, []string{"user"}},
+		{"aliased field", `{ me: user(id: "1") { posts { | } } }`, []string{"title"}, []string{"name"}},
+		{"unterminated document", "query {\n  user(id: \"1\") {\n    na|", []string{"name"}, []string{"user"}},
+		{"arguments", `{ users(| }`, []string{"filter", "first"}, []string{"id"}},
+		{"second argument", `{ users(first: 5, | }`, []string{"filter"}, nil},
+		{"input object fields", `{ users(filter: { | }) { id } }`, []string{"role", "nameContains"}, []string{"filter"}},
+		{"enum argument value", `{ users(filter: { role: | }) { id } }`, []string{"ADMIN", "MEMBER"}, nil},
+		{"enum list value", `{ users(filter: { roles: [ADMIN, | }) { id } }`, []string{"ADMIN", "MEMBER"}, nil},
+		{"boolean value", `{ user(id: "1") { posts(published: |) { id } } }`, []string{"true", "false"}, nil},
+		{"inline fragment type", `{ search(term: "x") { ... on | } }`, []string{"User", "Post", "Node", "SearchResult"}, []string{"Query", "Role"}},
+		{"inline fragment fields", `{ search(term: "x") { ... on Post { | } } }`, []string{"title"}, []string{"name"}},
+		{"fragment definition type", `fragment F on |`, []string{"User", "Query"}, []string{"Role", "UserFilter"}},
+		{"fragment definition fields", `fragment F on User { | }`, []string{"name"}, []string{"title"}},
+		{"fragment spread", "{ user(id: \"1\") { ...| } }\nfragment UserFields on User { id }", []string{"on", "UserFields"}, nil},
+		{"directives", `{ user(id: "1") @| }`, []string{"include", "skip"}, []string{"deprecated"}},
+		{"directive arguments", `{ user(id: "1") @include(| }`, []string{"if"}, nil},
+		{"variable types", `query Q($filter: |`, []string{"UserFilter", "Role", "ID"}, []string{"User", "__TypeKind"}},
+		{"after directive arguments", `{ user(id: "1") @include(if: true) { | } }`, []string{"name"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newLSPTestServer(t)
+			text, offset := splitCursor(t, tt.text)
+
+			labels := completionLabels(server.completion(text, offset))
+			for _, label := range tt.contains {
+				assert.Contains(t, labels, label)
+			}
+			for _, label := range tt.excludes {
+				assert.NotContains(t, labels, label)
+			}
+		})
+	}
+}
+
+func TestLSPCompletion_ItemDetails(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	text, offset := splitCursor(t, `{ user(id: "1") { | } }`)
+
+	items := server.completion(text, offset)
+	byLabel := map[string]lspCompletionItem{}
+	for _, item := range items {
+		byLabel[item.Label] = item
+	}
+
+	assert.Equal(t, "Role!", byLabel["role"].Detail)
+	assert.Equal(t, lspCompletionField, byLabel["role"].Kind)
+	assert.Equal(t, []int{lspTagDeprecated}, byLabel["legacyName"].Tags)
+	assert.Contains(t, byLabel["legacyName"].Documentation.Value, "**Deprecated:** Use name")
+
+	text, offset = splitCursor(t, `{ users(| }`)
+	items = server.completion(text, offset)
+	assert.Equal(t, "first: ", items[1].InsertText)
+}
+
+func TestLSPHover(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"field", `{ us|er(id: "1") { id } }`, []string{"Query.user(id: ID!): User", "Looks up a user."}},
+		{"nested field", `{ user(id: "1") { na|me } }`, []string{"User.name: String"}},
+		{"deprecated field", `{ user(id: "1") { legacy|Name } }`, []string{"User.legacyName: String", "**Deprecated:** Use name"}},
+		{"argument", `{ users(fir|st: 5) { id } }`, []string{"first: Int = 10", "Argument of `Query.users`"}},
+		{"input field", `{ users(filter: { ro|le: ADMIN }) { id } }`, []string{"role: Role", "Field of `UserFilter`"}},
+		{"enum value", `{ users(filter: { role: MEM|BER }) { id } }`, []string{"Role.MEMBER", "A regular member."}},
+		{"type condition", `{ search(term: "x") { ... on Us|er { id } } }`, []string{"type User implements Node", "A person with an account."}},
+		{"union type", `fragment F on Search|Result { __typename }`, []string{"union SearchResult = User | Post"}},
+		{"directive", `{ user(id: "1") @inc|lude(if: true) { id } }`, []string{"@include(if: Boolean!)"}},
+		{"variable type", `query Q($f: UserFil|ter) { users(filter: $f) { id } }`, []string{"input UserFilter"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newLSPTestServer(t)
+			text, offset := splitCursor(t, tt.text)
+
+			hover := server.hover(text, offset)
+			require.NotNil(t, hover)
+			assert.Equal(t, "markdown", hover.Contents.Kind)
+			for _, expected := range tt.expected {
+				assert.Contains(t, hover.Contents.Value, expected)
+			}
+		})
+	}
+}
+
+func TestLSPHover_UnknownName(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	text, offset := splitCursor(t, `{ user(id: "1") { nope| } }`)
+
+	assert.Nil(t, server.hover(text, offset))
+}
+
+func TestLSPHover_Range(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	text, offset := splitCursor(t, "{\n  us|er(id: \"1\") { id }\n}")
+
+	hover := server.hover(text, offset)
+	require.NotNil(t, hover)
+	assert.Equal(t, lspRange{Start: lspPosition{Line: 1, Character: 2}, End: lspPosition{Line: 1, Character: 6}}, *hover.Range)
+}
+
+func TestLSPDefinition(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected lspPosition
+	}{
+		// Fields with a description resolve to the field name, not the description
+		{"field with description", `{ us|er(id: "1") { id } }`, lspPosition{Line: 2, Character: 2}},
+		{"nested field", `{ user(id: "1") { na|me } }`, lspPosition{Line: 12, Character: 2}},
+		{"argument", `{ users(fil|ter: {}) { id } }`, lspPosition{Line: 3, Character: 8}},
+		{"type", `{ search(term: "x") { ... on Po|st { id } } }`, lspPosition{Line: 18, Character: 5}},
+		{"enum value with description", `{ users(filter: { role: MEM|BER }) { id } }`, lspPosition{Line: 32, Character: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newLSPTestServer(t)
+			text, offset := splitCursor(t, tt.text)
+
+			location := server.definition("file:///project/query.graphql", text, offset)
+			require.NotNil(t, location)
+			assert.Equal(t, "file:///project/schema.graphql", location.URI)
+			assert.Equal(t, tt.expected, location.Range.Start)
+		})
+	}
+}
+
+func TestLSPDefinition_SchemaInSeveralFiles(t *testing.T) {
+	dir := t.TempDir()
+	queryPath, userPath := filepath.Join(dir, "query.graphql"), filepath.Join(dir, "user.graphql")
+	schema, err := gqlparser.LoadSchema(
+		&ast.Source{Name: "query.graphql", Input: "type Query {\n  user: User\n}\n"},
+		&ast.Source{Name: "user.graphql", Input: "type User {\n  id: ID!\n}\n"},
+	)
+	require.NoError(t, err)
+	server := newLSPServer(schema, []string{queryPath, userPath}, &bytes.Buffer{})
+
+	text, offset := splitCursor(t, `{ user { i|d } }`)
+	location := server.definition("file:///project/op.graphql", text, offset)
+	require.NotNil(t, location)
+	assert.Equal(t, pathToURI(userPath), location.URI)
+	assert.Equal(t, lspPosition{Line: 1, Character: 2}, location.Range.Start)
+
+	assert.True(t, server.isSchemaDocument(pathToURI(queryPath)))
+	assert.True(t, server.isSchemaDocument(pathToURI(userPath)))
+	assert.False(t, server.isSchemaDocument("file:///project/op.graphql"))
+}
+
+func TestLSPDefinition_FragmentSpread(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	text, offset := splitCursor(t, "{ user(id: \"1\") { ...User|Fields } }\n\nfragment UserFields on User { id }")
+
+	location := server.definition("file:///project/query.graphql", text, offset)
+	require.NotNil(t, location)
+	assert.Equal(t, "file:///project/query.graphql", location.URI)
+	assert.Equal(t, lspRange{Start: lspPosition{Line: 2, Character: 9}, End: lspPosition{Line: 2, Character: 19}}, location.Range)
+}
+
+func TestLSPDefinition_BuiltInHasNoLocation(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	text, offset := splitCursor(t, `{ user(id: "1") @incl|ude(if: true) { id } }`)
+
+	assert.Nil(t, server.definition("file:///project/query.graphql", text, offset))
+}
+
+func TestLSPOffset_UTF16(t *testing.T) {
+	text := "# héllo 😀\n{ user }"
+
+	assert.Equal(t, strings.Index(text, "😀"), lspOffset(text, lspPosition{Line: 0, Character: 8}))
+	assert.Equal(t, strings.Index(text, "user"), lspOffset(text, lspPosition{Line: 1, Character: 2}))
+	assert.Equal(t, lspPosition{Line: 0, Character: 10}, lspPositionAt(text, strings.Index(text, "\n")))
+	assert.Equal(t, len(text), lspOffset(text, lspPosition{Line: 5, Character: 0}))
+}
+
+func TestLSPServe_Session(t *testing.T) {
+	server, out := newLSPTestServer(t)
+	uri := "file:///project/query.graphql"
+
+	in := frameLSPMessages(t,
+		map[string]any{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": map[string]any{}},
+		map[string]any{"jsonrpc": "2.0", "method": "initialized", "params": map[string]any{}},
+		map[string]any{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri, "languageId": "graphql", "version": 1, "text": `{ user(id: "1") { nam } }`},
+		}},
+		map[string]any{"jsonrpc": "2.0", "method": "textDocument/didChange", "params": map[string]any{
+			"textDocument":   map[string]any{"uri": uri, "version": 2},
+			"contentChanges": []any{map[string]any{"text": `{ user(id: "1") { name } }`}},
+		}},
+		map[string]any{"jsonrpc": "2.0", "id": 2, "method": "textDocument/hover", "params": map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     map[string]any{"line": 0, "character": 19},
+		}},
+		map[string]any{"jsonrpc": "2.0", "id": 3, "method": "workspace/symbol", "params": map[string]any{}},
+		map[string]any{"jsonrpc": "2.0", "id": 4, "method": "shutdown"},
+		map[string]any{"jsonrpc": "2.0", "method": "exit"},
+	)
+
+	require.NoError(t, server.serve(in))
+	messages := readLSPMessages(t, out)
+	require.Len(t, messages, 6)
+
+	capabilities := messages[0]["result"].(map[string]any)["capabilities"].(map[string]any)
+	assert.Equal(t, true, capabilities["hoverProvider"])
+	assert.Equal(t, true, capabilities["definitionProvider"])
+
+	assert.Equal(t, "textDocument/publishDiagnostics", messages[1]["method"])
+	diagnostics := messages[1]["params"].(map[string]any)["diagnostics"].([]any)
+	require.Len(t, diagnostics, 1)
+	diagnostic := diagnostics[0].(map[string]any)
+	assert.Equal(t, "FieldsOnCorrectType", diagnostic["code"])
+	assert.Equal(t, "gqlx", diagnostic["source"])
+	assert.Contains(t, diagnostic["message"], `Cannot query field "nam" on type "User".`)
+	assert.Contains(t, diagnostic["message"], "help: did you mean `name`?")
+	assert.Equal(t, map[string]any{
+		"start": map[string]any{"line": float64(0), "character": float64(18)},
+		"end":   map[string]any{"line": float64(0), "character": float64(21)},
+	}, diagnostic["range"])
+
+	// The fixed document clears the diagnostics
+	assert.Empty(t, messages[2]["params"].(map[string]any)["diagnostics"])
+
+	assert.Contains(t, fmt.Sprint(messages[3]["result"]), "User.name: String")
+	assert.Equal(t, float64(lspMethodNotFound), messages[4]["error"].(map[string]any)["code"])
+	assert.Contains(t, messages[5], "result")
+	assert.Nil(t, messages[5]["result"])
+}
+
+func TestLSPServe_ExitWithoutShutdown(t *testing.T) {
+	server, _ := newLSPTestServer(t)
+	in := frameLSPMessages(t, map[string]any{"jsonrpc": "2.0", "method": "exit"})
+
+	assert.Error(t, server.serve(in))
+}
+
+func TestLSPServe_SkipsSchemaDocument(t *testing.T) {
+	server, out := newLSPTestServer(t)
+	in := frameLSPMessages(t, map[string]any{"jsonrpc": "2.0", "method": "textDocument/didOpen", "params": map[string]any{
+		"textDocument": map[string]any{"uri": pathToURI(lspTestSchemaPath), "text": lspTestSchema},
+	}})
+
+	require.NoError(t, server.serve(in))
+	messages := readLSPMessages(t, out)
+	require.Len(t, messages, 1)
+	assert.Empty(t, messages[0]["params"].(map[string]any)["diagnostics"])
+}
@@ -0,0 +1,583 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// Mock Data
+//
+// The mock commands execute operations against generated data instead of real
+// resolvers. Every value is derived from a hash of the seed and the value's
+// path in the response (schema field names, arguments and list indices), so
+// the same operation always produces the same response for a given seed, and
+// a field selected twice under different aliases gets the same value.
+//
+// Fixtures override generated values. They are keyed by Type.field; object
+// fixtures supply values for nested fields, list fixtures set the length of
+// the list, and "__typename" picks the concrete type of an interface or union.
+
+type mockOptions struct {
+	seed       int64
+	listLength int
+	fixtures   string
+	scalars    string
+}
+
+// addMockFlags adds the flags shared by the mock subcommands.
+func addMockFlags(cmd *cobra.Command, opts *mockOptions) {
+	cmd.Flags().Int64Var(&opts.seed, "seed", 0, "Seed for generated values; the same seed always produces the same data")
+	cmd.Flags().IntVar(&opts.listLength, "list-length", 2, "Number of items generated for list fields")
+	cmd.Flags().StringVar(&opts.fixtures, "fixtures", "", "JSON or YAML file of values keyed by Type.field")
+	cmd.Flags().StringVar(&opts.scalars, "scalars", "", "JSON or YAML file configuring value generators for scalars")
+}
+
+// orderedObject is a JSON object that keeps keys in insertion order, so that
+// responses follow the order of the selection set like a real server.
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newOrderedObject() *orderedObject {
+	return &orderedObject{values: map[string]any{}}
+}
+
+func (o *orderedObject) Set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+func (o *orderedObject) Get(key string) any {
+	return o.values[key]
+}
+
+func (o *orderedObject) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// loadMockConfig reads a fixtures or scalars file into target. Files ending in
+// .yaml or .yml are parsed as YAML, everything else as JSON.
+func loadMockConfig(path string, name string, target any) error {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(bytes, target)
+	default:
+		err = json.Unmarshal(bytes, target)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s file %s: %w", name, path, err)
+	}
+	return nil
+}
+
+// validateMockFixtures checks that every fixture key names a field of an
+// object or interface type in the schema.
+func validateMockFixtures(schema *ast.Schema, fixtures map[string]any) error {
+	for key := range fixtures {
+		typeName, fieldName, ok := strings.Cut(key, ".")
+		if !ok || typeName == "" || fieldName == "" {
+			return fmt.Errorf("fixture key '%s' must be in the form Type.field", key)
+		}
+		if err := validateTypeExists(schema, typeName, "type"); err != nil {
+			return fmt.Errorf("fixture '%s': %w", key, err)
+		}
+		def := schema.Types[typeName]
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			return fmt.Errorf("fixture '%s': '%s' is not an object or interface (it's a %s)", key, typeName, kindToString(string(def.Kind)))
+		}
+		if def.Fields.ForName(fieldName) == nil {
+			if suggestion := findClosest(fieldName, pluck(def.Fields, func(f *ast.FieldDefinition) string { return f.Name })); suggestion != "" {
+				return fmt.Errorf("fixture '%s': field '%s' does not exist on type '%s', did you mean '%s'?", key, fieldName, typeName, suggestion)
+			}
+			return fmt.Errorf("fixture '%s': field '%s' does not exist on type '%s'", key, fieldName, typeName)
+		}
+	}
+	return nil
+}
+
+// mockScalarGenerators are the built-in generators for --scalars.
+var mockScalarGenerators = []string{"boolean", "date", "datetime", "email", "float", "int", "string", "time", "url", "uuid"}
+
+// mockScalarGenerator configures how values of a scalar are generated. Exactly
+// one of its fields is set:
+//
+//	DateTime: {generator: datetime}
+//	Currency: {values: [USD, EUR]}
+//	URL:      {template: "https://example.com/{field}/{n}"}
+//	JSON:     {value: {}}
+type mockScalarGenerator struct {
+	Value     any    `json:"value" yaml:"value"`
+	Values    []any  `json:"values" yaml:"values"`
+	Template  string `json:"template" yaml:"template"`
+	Generator string `json:"generator" yaml:"generator"`
+}
+
+// validateMockScalars checks that every configured scalar exists and that
+// every generator is well-formed.
+func validateMockScalars(schema *ast.Schema, scalars map[string]*mockScalarGenerator) error {
+	for name, gen := range scalars {
+		if err := validateTypeExists(schema, name, "scalar"); err != nil {
+			return err
+		}
+		if kind := schema.Types[name].Kind; kind != ast.Scalar {
+			return fmt.Errorf("'%s' is not a scalar (it's a %s)", name, kindToString(string(kind)))
+		}
+		if gen == nil {
+			return fmt.Errorf("scalar '%s' must set one of value, values, template or generator", name)
+		}
+
+		set := 0
+		for _, ok := range []bool{gen.Value != nil, gen.Values != nil, gen.Template != "", gen.Generator != ""} {
+			if ok {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("scalar '%s' must set exactly one of value, values, template or generator", name)
+		}
+		if gen.Values != nil && len(gen.Values) == 0 {
+			return fmt.Errorf("scalar '%s' must list at least one value", name)
+		}
+		if gen.Generator != "" && !slices.Contains(mockScalarGenerators, gen.Generator) {
+			return fmt.Errorf("scalar '%s': generator must be one of %s, got '%s'", name, strings.Join(mockScalarGenerators, ", "), gen.Generator)
+		}
+	}
+	return nil
+}
+
+// mockEpoch is the earliest date produced by the date and time generators.
+var mockEpoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// generate produces a value for a field of typeName from the hash of its path.
+func (gen *mockScalarGenerator) generate(h uint64, scalar, fieldName, typeName string) any {
+	switch {
+	case gen.Value != nil:
+		return gen.Value
+	case gen.Values != nil:
+		return gen.Values[h%uint64(len(gen.Values))]
+	case gen.Template != "":
+		return strings.NewReplacer(
+			"{n}", strconv.FormatUint(h%1000, 10),
+			"{field}", fieldName,
+			"{type}", typeName,
+			"{scalar}", scalar,
+		).Replace(gen.Template)
+	}
+
+	moment := mockEpoch.Add(time.Duration(h%(365*24*60*60)) * time.Second)
+	switch gen.Generator {
+	case "boolean":
+		return h%2 == 0
+	case "date":
+		return moment.Format(time.DateOnly)
+	case "datetime":
+		return moment.Format(time.RFC3339)
+	case "email":
+		return fmt.Sprintf("%s-%d@example.com", fieldName, h%1000)
+	case "float":
+		return float64(h%10000) / 100
+	case "int":
+		return int(h % 100)
+	case "time":
+		return moment.Format(time.TimeOnly)
+	case "url":
+		return fmt.Sprintf("https://example.com/%s/%d", fieldName, h%1000)
+	case "uuid":
+		h2 := h*0x9e3779b97f4a7c15 + 1
+		return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x", h>>32, h>>16&0xffff, h&0xfff, h2>>52, h2&0xffffffffffff)
+	default:
+		return fmt.Sprintf("%s-%d", fieldName, h%1000)
+	}
+}
+
+// mockGenerator resolves operations against generated data.
+type mockGenerator struct {
+	schema     *ast.Schema
+	seed       int64
+	listLength int
+	fixtures   map[string]any
+	scalars    map[string]*mockScalarGenerator
+
+	// Built on first use, once, since mock serve resolves requests
+	// concurrently
+	introspectionOnce sync.Once
+	introspection     map[string]any
+}
+
+// newMockGenerator creates a generator from the mock flags, loading and
+// validating the fixtures and scalars files if given.
+func newMockGenerator(schema *ast.Schema, opts *mockOptions) (*mockGenerator, error) {
+	if opts.listLength < 0 {
+		return nil, fmt.Errorf("--list-length must not be negative")
+	}
+
+	g := &mockGenerator{
+		schema:     schema,
+		seed:       opts.seed,
+		listLength: opts.listLength,
+		fixtures:   map[string]any{},
+		scalars:    map[string]*mockScalarGenerator{},
+	}
+
+	if opts.fixtures != "" {
+		if err := loadMockConfig(opts.fixtures, "fixtures", &g.fixtures); err != nil {
+			return nil, err
+		}
+		if err := validateMockFixtures(schema, g.fixtures); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.scalars != "" {
+		if err := loadMockConfig(opts.scalars, "scalars", &g.scalars); err != nil {
+			return nil, err
+		}
+		if err := validateMockScalars(schema, g.scalars); err != nil {
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+// selectOperation picks the operation to execute from a document. The name
+// may only be omitted when the document contains a single operation.
+func selectOperation(doc *ast.QueryDocument, operationName string) (*ast.OperationDefinition, error) {
+	if operationName == "" {
+		if len(doc.Operations) != 1 {
+			return nil, fmt.Errorf("an operation name is required when the document contains %d operations", len(doc.Operations))
+		}
+		return doc.Operations[0], nil
+	}
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		if suggestion := findClosest(operationName, pluck(doc.Operations, func(o *ast.OperationDefinition) string { return o.Name })); suggestion != "" {
+			return nil, fmt.Errorf("operation '%s' does not exist in the document, did you mean '%s'?", operationName, suggestion)
+		}
+		return nil, fmt.Errorf("operation '%s' does not exist in the document", operationName)
+	}
+	return op, nil
+}
+
+// execute resolves an operation with the given variables and returns the
+// response data.
+func (g *mockGenerator) execute(op *ast.OperationDefinition, variables map[string]any) (*orderedObject, error) {
+	if op.Operation == ast.Subscription {
+		return nil, fmt.Errorf("subscriptions are not supported by mock data")
+	}
+	root := operationRootType(g.schema, op)
+	if root == nil {
+		return nil, fmt.Errorf("schema does not define a %s type", operationKindName(op))
+	}
+
+	coerced, err := validator.VariableValues(g.schema, op, variables)
+	if err != nil {
+		return nil, err
+	}
+	if coerced == nil {
+		coerced = map[string]any{}
+	}
+
+	return g.resolveObject(root, op.SelectionSet, root.Name, nil, false, coerced), nil
+}
+
+// hash derives a deterministic number from the seed and a response path.
+func (g *mockGenerator) hash(path string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatInt(g.seed, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	return h.Sum64()
+}
+
+// fieldPath extends a response path with a field, including its arguments so
+// that the same field called with different arguments gets different values.
+func fieldPath(path string, field *ast.Field, variables map[string]any) string {
+	path += "." + field.Name
+	if len(field.Arguments) > 0 {
+		args, err := json.Marshal(field.ArgumentMap(variables))
+		if err == nil {
+			path += string(args)
+		}
+	}
+	return path
+}
+
+// fixture returns the fixture for a field of an object type, falling back to
+// fixtures on the interfaces it implements.
+func (g *mockGenerator) fixture(object *ast.Definition, fieldName string) (any, bool) {
+	if value, ok := g.fixtures[object.Name+"."+fieldName]; ok {
+		return value, true
+	}
+	for _, iface := range object.Interfaces {
+		if value, ok := g.fixtures[iface+"."+fieldName]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// resolveObject resolves a selection set on an object type. Values in source
+// take precedence over fixtures and generated data; when exhaustive is set,
+// fields missing from source resolve to null instead of being generated.
+func (g *mockGenerator) resolveObject(object *ast.Definition, selectionSet ast.SelectionSet, path string, source map[string]any, exhaustive bool, variables map[string]any) *orderedObject {
+	result := newOrderedObject()
+
+	for _, field := range collectFieldsWithVariables(g.schema, selectionSet, object, variables) {
+		node := field.Fields[0]
+		if node.Name == "__typename" {
+			result.Set(field.ResponseKey, object.Name)
+			continue
+		}
+
+		def := object.Fields.ForName(node.Name)
+		if def == nil {
+			continue
+		}
+
+		value, ok := source[node.Name]
+		if !ok && !exhaustive {
+			value, ok = g.fixture(object, node.Name)
+		}
+		exhaustiveField := exhaustive
+		if !ok && object == g.schema.Query && strings.HasPrefix(node.Name, "__") {
+			value, ok = g.introspectionField(node, variables)
+			exhaustiveField = true
+		}
+
+		ctx := &mockField{
+			field:      field,
+			parent:     object,
+			exhaustive: exhaustiveField,
+			variables:  variables,
+		}
+		result.Set(field.ResponseKey, g.resolveValue(ctx, def.Type, fieldPath(path, node, variables), value, ok))
+	}
+
+	return result
+}
+
+// mockField is the field being resolved by resolveValue.
+type mockField struct {
+	field      *collectedField
+	parent     *ast.Definition
+	exhaustive bool
+	variables  map[string]any
+}
+
+// resolveValue resolves a value of the given type. When hasValue is set, value
+// comes from a fixture (or introspection data) and is used instead of
+// generated data.
+func (g *mockGenerator) resolveValue(ctx *mockField, t *ast.Type, path string, value any, hasValue bool) any {
+	if hasValue && value == nil {
+		return nil
+	}
+	if !hasValue && ctx.exhaustive {
+		return nil
+	}
+
+	if t.Elem != nil {
+		var items []any
+		if hasValue {
+			if list, ok := value.([]any); ok {
+				items = list
+			} else {
+				// A single value for a list field is used for every item
+				for range g.listLength {
+					items = append(items, value)
+				}
+			}
+		} else {
+			items = make([]any, g.listLength)
+		}
+
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i] = g.resolveValue(ctx, t.Elem, path+"."+strconv.Itoa(i), item, hasValue)
+		}
+		return result
+	}
+
+	def := g.schema.Types[t.Name()]
+	switch def.Kind {
+	case ast.Scalar, ast.Enum:
+		if hasValue {
+			return value
+		}
+		return g.leafValue(def, ctx.field.Fields[0].Name, ctx.parent.Name, path)
+	default:
+		source, _ := value.(map[string]any)
+		object := g.objectType(def, source, path)
+		if object == nil {
+			return nil
+		}
+		return g.resolveObject(object, ctx.field.SelectionSet(), path, source, ctx.exhaustive, ctx.variables)
+	}
+}
+
+// objectType picks the concrete object type for a value. For interfaces and
+// unions the source's __typename is used if it names a possible type;
+// otherwise a possible type is picked from the path.
+func (g *mockGenerator) objectType(def *ast.Definition, source map[string]any, path string) *ast.Definition {
+	candidates := concreteTypes(g.schema, def)
+	if len(candidates) == 0 {
+		return nil
+	}
+	if typename, ok := source["__typename"].(string); ok {
+		for _, candidate := range candidates {
+			if candidate.Name == typename {
+				return candidate
+			}
+		}
+	}
+	return candidates[g.hash(path+".__typename")%uint64(len(candidates))]
+}
+
+// leafValue generates a scalar or enum value for a field of typeName. The names
+// are used in placeholder strings and scalar templates.
+func (g *mockGenerator) leafValue(def *ast.Definition, fieldName, typeName, path string) any {
+	h := g.hash(path)
+
+	if def.Kind == ast.Enum {
+		values := filterSlice(def.EnumValues, func(v *ast.EnumValueDefinition) bool {
+			_, deprecated := deprecationReason(v.Directives)
+			return !deprecated
+		})
+		if len(values) == 0 {
+			values = def.EnumValues
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return values[h%uint64(len(values))].Name
+	}
+
+	if gen, ok := g.scalars[def.Name]; ok {
+		return gen.generate(h, def.Name, fieldName, typeName)
+	}
+
+	switch def.Name {
+	case "Int":
+		return int(h % 100)
+	case "Float":
+		return float64(h%10000) / 100
+	case "Boolean":
+		return h%2 == 0
+	case "ID":
+		return fmt.Sprintf("%s-%d", typeName, h%1000)
+	case "String":
+		return fmt.Sprintf("%s-%d", fieldName, h%1000)
+	default:
+		return fmt.Sprintf("%s-%d", def.Name, h%1000)
+	}
+}
+
+// introspectionField returns the data for the __schema and __type meta fields,
+// from the same introspection result as gqlx convert --to introspection.
+func (g *mockGenerator) introspectionField(field *ast.Field, variables map[string]any) (any, bool) {
+	g.introspectionOnce.Do(func() {
+		bytes, err := json.Marshal(buildIntrospection(g.schema).Schema)
+		if err != nil || json.Unmarshal(bytes, &g.introspection) != nil {
+			g.introspection = nil
+		}
+	})
+	if g.introspection == nil {
+		return nil, false
+	}
+
+	switch field.Name {
+	case "__schema":
+		return g.introspection, true
+	case "__type":
+		name, _ := field.ArgumentMap(variables)["name"].(string)
+		types, _ := g.introspection["types"].([]any)
+		for _, typ := range types {
+			if typ, ok := typ.(map[string]any); ok && typ["name"] == name {
+				return typ, true
+			}
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+func NewMockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Serves and executes operations against generated mock data",
+		Long: `Executes operations against schema-shaped fake data, without any resolvers.
+
+Values are deterministic: the same operation returns the same data for a given
+--seed. Enums use values defined in the schema, and lists have --list-length
+items.
+
+Generated values can be overridden with a JSON or YAML fixtures file keyed by
+Type.field. Object values fill in nested fields, lists set the number of items,
+and "__typename" picks the concrete type of an interface or union:
+
+  {
+    "Query.me": {"name": "Ada Lovelace", "role": "ADMIN"},
+    "User.friends": [{"name": "Charles"}, {"name": "Mary"}],
+    "Query.search": [{"__typename": "Post"}]
+  }
+
+Values of custom scalars are strings like "DateTime-123" unless a generator is
+configured in a JSON or YAML file passed to --scalars. Each scalar sets exactly
+one of a fixed value, a list of values to pick from, a template ({n}, {field},
+{type} and {scalar} are replaced), or a built-in generator (boolean, date,
+datetime, email, float, int, string, time, url, uuid):
+
+  DateTime: {generator: datetime}
+  Currency: {values: [USD, EUR, GBP]}
+  URL: {template: "https://example.com/{type}/{n}"}`,
+	}
+
+	cmd.AddCommand(NewMockServeCmd())
+	cmd.AddCommand(NewMockRunCmd())
+
+	return cmd
+}
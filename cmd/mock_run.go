@@ -0,0 +1,124 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type mockRunOptions struct {
+	mock      mockOptions
+	variables string
+	operation string
+}
+
+// loadVariables reads operation variables from a JSON file, or from inline
+// JSON if the value starts with "{".
+func loadVariables(value string) (map[string]any, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	bytes := []byte(value)
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var err error
+		bytes, err = os.ReadFile(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read variables: %w", err)
+		}
+	}
+
+	var variables map[string]any
+	if err := json.Unmarshal(bytes, &variables); err != nil {
+		return nil, fmt.Errorf("variables must be a JSON object: %w", err)
+	}
+	return variables, nil
+}
+
+func NewMockRunCmd() *cobra.Command {
+	opts := &mockRunOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "run <query file>",
+		Short: "Executes an operation against mock data and prints the response",
+		Long: `Executes an operation against mock data and prints the JSON response, without
+starting a server.
+
+The operation is validated against the schema first, with the same diagnostics
+as gqlx validate. Fragments, aliases, __typename, @skip and @include are
+resolved like a real server would; selections on interfaces and unions pick a
+concrete type for each value.
+
+If the document contains several operations, pick one with --operation.
+
+The --format flag is ignored; output is always JSON.`,
+		Example: `  # See what a query's response looks like
+  gqlx mock run query.graphql
+
+  # Pass variables from a file or inline
+  gqlx mock run query.graphql --variables vars.json
+  gqlx mock run query.graphql --variables '{"id": "1"}'
+
+  # Run one operation from a document with several
+  gqlx mock run operations.graphql --operation GetUser
+
+  # Generate realistic custom scalars
+  gqlx mock run query.graphql --scalars scalars.yaml`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMockRun(cmd, args[0], opts)
+		},
+	}
+
+	addMockFlags(cmd, &opts.mock)
+	cmd.Flags().StringVar(&opts.variables, "variables", "", "JSON file of operation variables, or inline JSON")
+	cmd.Flags().StringVar(&opts.operation, "operation", "", "Name of the operation to run")
+
+	return cmd
+}
+
+func runMockRun(cmd *cobra.Command, path string, opts *mockRunOptions) error {
+	variables, err := loadVariables(opts.variables)
+	if err != nil {
+		return err
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	g, err := newMockGenerator(schema, &opts.mock)
+	if err != nil {
+		return err
+	}
+
+	doc, err := loadOperationFile(cmd, schema, path)
+	if err != nil {
+		return err
+	}
+
+	op, err := selectOperation(doc, opts.operation)
+	if err != nil {
+		return err
+	}
+
+	data, err := g.execute(op, variables)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(&graphQLResponse{Data: data}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	return nil
+}
@@ -0,0 +1,195 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mockRunTestSchema = `
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	createdAt: DateTime!
+	website: URL
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+}
+
+union SearchResult = User | Post
+
+scalar DateTime
+scalar URL
+
+type Query {
+	user(id: ID!): User
+	search(term: String!): [SearchResult!]!
+}
+`
+
+// setupMockRunTest writes the schema, query and any extra files to a temp
+// directory and returns their paths.
+func setupMockRunTest(t *testing.T, query string, files map[string]string) (schemaPath string, queryPath string, dir string) {
+	t.Helper()
+	dir = t.TempDir()
+	schemaPath = filepath.Join(dir, "schema.graphql")
+	queryPath = filepath.Join(dir, "query.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(mockRunTestSchema), 0644))
+	require.NoError(t, os.WriteFile(queryPath, []byte(query), 0644))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return schemaPath, queryPath, dir
+}
+
+func decodeMockResponse(t *testing.T, stdout string) map[string]any {
+	t.Helper()
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &response))
+	return response["data"].(map[string]any)
+}
+
+func TestMockRun_ResolvesFragmentsAndAliases(t *testing.T) {
+	schemaPath, queryPath, dir := setupMockRunTest(t, `
+query GetUser($id: ID!) {
+	user(id: $id) {
+		...UserFields
+		displayName: name
+	}
+}
+
+fragment UserFields on User {
+	__typename
+	id
+}
+`, map[string]string{"vars.json": `{"id": "42"}`})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath, "--variables", filepath.Join(dir, "vars.json")})
+	require.NoError(t, err)
+
+	user := decodeMockResponse(t, stdout)["user"].(map[string]any)
+	assert.Equal(t, "User", user["__typename"])
+	assert.Regexp(t, `^User-\d+$`, user["id"])
+	assert.Regexp(t, `^name-\d+$`, user["displayName"])
+	assert.Regexp(t, `(?s)"__typename".*"id".*"displayName"`, stdout)
+}
+
+func TestMockRun_SelectsConcreteTypesForAbstractSelections(t *testing.T) {
+	schemaPath, queryPath, _ := setupMockRunTest(t, `
+{
+	search(term: "x") {
+		__typename
+		... on Node { id }
+		... on Post { title }
+	}
+}
+`, nil)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath, "--list-length", "8"})
+	require.NoError(t, err)
+
+	results := decodeMockResponse(t, stdout)["search"].([]any)
+	require.Len(t, results, 8)
+	for _, result := range results {
+		result := result.(map[string]any)
+		assert.Contains(t, result, "id")
+		if result["__typename"] == "Post" {
+			assert.Contains(t, result, "title")
+		} else {
+			assert.Equal(t, "User", result["__typename"])
+			assert.NotContains(t, result, "title")
+		}
+	}
+}
+
+func TestMockRun_UsesConfiguredScalarGenerators(t *testing.T) {
+	schemaPath, queryPath, dir := setupMockRunTest(t, `{ user(id: "1") { createdAt website } }`, map[string]string{
+		"scalars.yaml": `
+DateTime: {generator: datetime}
+URL: {template: "https://example.com/{type}/{n}"}
+`,
+	})
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath, "--scalars", filepath.Join(dir, "scalars.yaml")})
+	require.NoError(t, err)
+
+	user := decodeMockResponse(t, stdout)["user"].(map[string]any)
+	assert.Regexp(t, `^2024-\d\d-\d\dT\d\d:\d\d:\d\dZ$`, user["createdAt"])
+	assert.Regexp(t, `^https://example.com/User/\d+$`, user["website"])
+}
+
+func TestMockRun_DefaultsCustomScalarsToStrings(t *testing.T) {
+	schemaPath, queryPath, _ := setupMockRunTest(t, `{ user(id: "1") { createdAt } }`, nil)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath})
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^DateTime-\d+$`, decodeMockResponse(t, stdout)["user"].(map[string]any)["createdAt"])
+}
+
+func TestMockRun_InvalidScalarConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		scalars string
+		err     string
+	}{
+		{"unknown scalar", `{"DateTim": {"generator": "datetime"}}`, "scalar 'DateTim' does not exist in schema, did you mean 'DateTime'?"},
+		{"not a scalar", `{"User": {"value": 1}}`, "'User' is not a scalar (it's a type)"},
+		{"unknown generator", `{"DateTime": {"generator": "timestamp"}}`, "generator must be one of boolean, date, datetime"},
+		{"several options", `{"DateTime": {"value": "x", "generator": "datetime"}}`, "must set exactly one of value, values, template or generator"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemaPath, queryPath, dir := setupMockRunTest(t, `{ user(id: "1") { id } }`, map[string]string{"scalars.json": tt.scalars})
+
+			_, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath, "--scalars", filepath.Join(dir, "scalars.json")})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestMockRun_SelectsOperation(t *testing.T) {
+	schemaPath, queryPath, _ := setupMockRunTest(t, `
+query A { user(id: "1") { id } }
+query B { user(id: "1") { name } }
+`, nil)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath, "--operation", "B"})
+	require.NoError(t, err)
+	assert.Contains(t, decodeMockResponse(t, stdout)["user"], "name")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "an operation name is required when the document contains 2 operations")
+}
+
+func TestMockRun_MissingRequiredVariable(t *testing.T) {
+	schemaPath, queryPath, _ := setupMockRunTest(t, `query GetUser($id: ID!) { user(id: $id) { id } }`, nil)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "variable.id must be defined")
+}
+
+func TestMockRun_InvalidQuery(t *testing.T) {
+	schemaPath, queryPath, _ := setupMockRunTest(t, `{ user(id: "1") { nam } }`, nil)
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"-s", schemaPath, "mock", "run", queryPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not valid against the schema")
+	assert.Contains(t, stderr, "did you mean `name`?")
+}
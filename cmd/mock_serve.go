@@ -0,0 +1,224 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type mockServeOptions struct {
+	mock mockOptions
+	host string
+	port int
+}
+
+// graphQLRequest is the body of a GraphQL-over-HTTP request.
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// graphQLResponse is the body of a GraphQL-over-HTTP response. Data is
+// omitted when the request fails before execution.
+type graphQLResponse struct {
+	Data   *orderedObject `json:"data,omitempty"`
+	Errors []graphQLError `json:"errors,omitempty"`
+}
+
+type graphQLError struct {
+	Message    string         `json:"message"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// validationResponse converts validation errors to a response, with the
+// validation rule and any suggestion from gqlx validate as extensions.
+func validationResponse(result *ValidationResult, schema *ast.Schema) *graphQLResponse {
+	response := &graphQLResponse{}
+	for _, err := range result.Errors {
+		gqlErr := graphQLError{Message: err.Message, Locations: err.Locations}
+		extensions := map[string]any{}
+		if err.Rule != "" {
+			extensions["rule"] = err.Rule
+		}
+		if suggestion := errorSuggestion(err, schema); suggestion != "" {
+			extensions["suggestion"] = suggestion
+		}
+		if len(extensions) > 0 {
+			gqlErr.Extensions = extensions
+		}
+		response.Errors = append(response.Errors, gqlErr)
+	}
+	return response
+}
+
+func errorResponse(err error) *graphQLResponse {
+	return &graphQLResponse{Errors: []graphQLError{{Message: err.Error()}}}
+}
+
+// parseGraphQLRequest reads a request from a POST body or GET query parameters.
+func parseGraphQLRequest(r *http.Request) (*graphQLRequest, error) {
+	req := &graphQLRequest{}
+	if r.Method == http.MethodGet {
+		params := r.URL.Query()
+		req.Query = params.Get("query")
+		req.OperationName = params.Get("operationName")
+		if variables := params.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, fmt.Errorf("variables must be a JSON object: %w", err)
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, fmt.Errorf("request body must be a JSON object: %w", err)
+	}
+
+	if req.Query == "" {
+		return nil, fmt.Errorf("request is missing a query")
+	}
+	return req, nil
+}
+
+// newMockHandler returns an HTTP handler that serves GraphQL requests at
+// /graphql. Operations are validated like gqlx validate and resolved against
+// the generator's mock data.
+func newMockHandler(g *mockGenerator) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		// Frontends under development are usually served from another origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+		writeResponse := func(status int, response *graphQLResponse) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(response)
+		}
+
+		switch r.Method {
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodGet, http.MethodPost:
+		default:
+			w.Header().Set("Allow", "GET, POST, OPTIONS")
+			writeResponse(http.StatusMethodNotAllowed, errorResponse(fmt.Errorf("method %s is not allowed", r.Method)))
+			return
+		}
+
+		req, err := parseGraphQLRequest(r)
+		if err != nil {
+			writeResponse(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		doc, result := loadValidQuery("request", req.Query, g.schema)
+		if !result.Valid {
+			writeResponse(http.StatusOK, validationResponse(result, g.schema))
+			return
+		}
+
+		op, err := selectOperation(doc, req.OperationName)
+		if err != nil {
+			writeResponse(http.StatusOK, errorResponse(err))
+			return
+		}
+		if r.Method == http.MethodGet && op.Operation != ast.Query {
+			w.Header().Set("Allow", "POST")
+			writeResponse(http.StatusMethodNotAllowed, errorResponse(fmt.Errorf("%s operations must be sent with POST", op.Operation)))
+			return
+		}
+
+		data, err := g.execute(op, req.Variables)
+		if err != nil {
+			writeResponse(http.StatusOK, errorResponse(err))
+			return
+		}
+		writeResponse(http.StatusOK, &graphQLResponse{Data: data})
+	})
+	return mux
+}
+
+func NewMockServeCmd() *cobra.Command {
+	opts := &mockServeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Runs a local GraphQL server that returns mock data",
+		Long: `Runs a GraphQL server at http://<host>:<port>/graphql that answers every
+operation with mock data shaped like the schema.
+
+Requests are sent as JSON with POST ({"query", "operationName", "variables"})
+or as query parameters with GET. Operations are validated against the schema
+first; invalid operations get the same errors as gqlx validate, with the
+validation rule and suggestions under "extensions".
+
+Introspection queries are answered from the schema, so GraphQL IDEs and client
+tooling can be pointed at the server. Subscriptions are not supported.`,
+		Example: `  # Serve mock data on http://localhost:4000/graphql
+  gqlx mock serve
+
+  # Use a different port and seed
+  gqlx mock serve --port 8080 --seed 42
+
+  # Return five items for every list and override values with fixtures
+  gqlx mock serve --list-length 5 --fixtures fixtures.yaml`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMockServe(cmd, opts)
+		},
+	}
+
+	addMockFlags(cmd, &opts.mock)
+	cmd.Flags().StringVar(&opts.host, "host", "localhost", "Host to listen on")
+	cmd.Flags().IntVarP(&opts.port, "port", "p", 4000, "Port to listen on")
+
+	return cmd
+}
+
+func runMockServe(cmd *cobra.Command, opts *mockServeOptions) error {
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	g, err := newMockGenerator(schema, &opts.mock)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(opts.host, strconv.Itoa(opts.port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	server := &http.Server{Handler: newMockHandler(g)}
+	go func() {
+		<-ctx.Done()
+		server.Shutdown(context.Background())
+	}()
+
+	address := net.JoinHostPort(opts.host, strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+	fmt.Fprintf(cmd.ErrOrStderr(), "Mock GraphQL server listening on http://%s/graphql\n", address)
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const mockTestSchema = `
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	age: Int!
+	role: Role!
+	friends: [User!]!
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+}
+
+union SearchResult = User | Post
+
+enum Role {
+	ADMIN
+	MEMBER
+	GUEST @deprecated
+}
+
+type Query {
+	me: User
+	user(id: ID!): User
+	search(term: String!): [SearchResult!]!
+}
+
+type Mutation {
+	rename(id: ID!, name: String!): User!
+}
+`
+
+func loadMockTestSchema(t *testing.T) *ast.Schema {
+	t.Helper()
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: mockTestSchema})
+	require.NoError(t, err)
+	return schema
+}
+
+func newMockTestServer(t *testing.T, opts *mockOptions) *httptest.Server {
+	t.Helper()
+	g, err := newMockGenerator(loadMockTestSchema(t), opts)
+	require.NoError(t, err)
+	server := httptest.NewServer(newMockHandler(g))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func postGraphQL(t *testing.T, server *httptest.Server, request graphQLRequest) (int, map[string]any) {
+	t.Helper()
+	body, err := json.Marshal(request)
+	require.NoError(t, err)
+	resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(string(body)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var result map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	return resp.StatusCode, result
+}
+
+func TestMockServe_ReturnsSchemaShapedData(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 3})
+
+	status, result := postGraphQL(t, server, graphQLRequest{
+		Query: `{ me { __typename id fullName: name age role friends { name } } }`,
+	})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotContains(t, result, "errors")
+	me := result["data"].(map[string]any)["me"].(map[string]any)
+	assert.Equal(t, "User", me["__typename"])
+	assert.Regexp(t, `^User-\d+$`, me["id"])
+	assert.Regexp(t, `^name-\d+$`, me["fullName"])
+	assert.IsType(t, float64(0), me["age"])
+	assert.Contains(t, []any{"ADMIN", "MEMBER"}, me["role"])
+	assert.Len(t, me["friends"], 3)
+}
+
+func TestMockServe_KeepsSelectionOrder(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	body := `{"query": "{ me { role name id } }"}`
+	resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+	assert.Regexp(t, `^\{"data":\{"me":\{"role":.*,"name":.*,"id":.*\}\}\}$`, string(raw))
+}
+
+func TestMockServe_IsDeterministicPerSeed(t *testing.T) {
+	query := graphQLRequest{Query: `{ me { id name age friends { id } } }`}
+
+	_, first := postGraphQL(t, newMockTestServer(t, &mockOptions{seed: 1, listLength: 2}), query)
+	_, again := postGraphQL(t, newMockTestServer(t, &mockOptions{seed: 1, listLength: 2}), query)
+	_, other := postGraphQL(t, newMockTestServer(t, &mockOptions{seed: 2, listLength: 2}), query)
+
+	assert.Equal(t, first, again)
+	assert.NotEqual(t, first, other)
+}
+
+func TestMockServe_SameFieldUnderAliasesHasSameValue(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query: `{ a: user(id: "1") { name } b: user(id: "1") { name } c: user(id: "2") { name } }`,
+	})
+
+	data := result["data"].(map[string]any)
+	assert.Equal(t, data["a"], data["b"])
+	assert.NotEqual(t, data["a"], data["c"])
+}
+
+func TestMockServe_ResolvesAbstractTypes(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 10})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query: `{ search(term: "x") { __typename ... on User { name } ... on Post { title } } }`,
+	})
+
+	for _, item := range result["data"].(map[string]any)["search"].([]any) {
+		item := item.(map[string]any)
+		switch item["__typename"] {
+		case "User":
+			assert.Contains(t, item, "name")
+			assert.NotContains(t, item, "title")
+		case "Post":
+			assert.Contains(t, item, "title")
+			assert.NotContains(t, item, "name")
+		default:
+			t.Fatalf("unexpected __typename %v", item["__typename"])
+		}
+	}
+}
+
+func TestMockServe_AppliesVariablesAndSkip(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query:     `query Me($withAge: Boolean!) { me { name age @include(if: $withAge) } }`,
+		Variables: map[string]any{"withAge": false},
+	})
+
+	me := result["data"].(map[string]any)["me"].(map[string]any)
+	assert.Contains(t, me, "name")
+	assert.NotContains(t, me, "age")
+}
+
+func TestMockServe_RejectsInvalidVariables(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query: `query Me($withAge: Boolean!) { me { age @include(if: $withAge) } }`,
+	})
+
+	assert.NotContains(t, result, "data")
+	assert.Len(t, result["errors"], 1)
+}
+
+func TestMockServe_ReportsValidationErrors(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	status, result := postGraphQL(t, server, graphQLRequest{Query: `{ me { nam } }`})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotContains(t, result, "data")
+	errs := result["errors"].([]any)
+	require.Len(t, errs, 1)
+	gqlErr := errs[0].(map[string]any)
+	assert.Contains(t, gqlErr["message"], `Cannot query field "nam" on type "User"`)
+	assert.Equal(t, "FieldsOnCorrectType", gqlErr["extensions"].(map[string]any)["rule"])
+	assert.Equal(t, "did you mean `name`?", gqlErr["extensions"].(map[string]any)["suggestion"])
+	assert.NotEmpty(t, gqlErr["locations"])
+}
+
+func TestMockServe_SelectsOperationByName(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+	query := `query A { me { id } } query B { me { name } }`
+
+	_, result := postGraphQL(t, server, graphQLRequest{Query: query, OperationName: "B"})
+	assert.Contains(t, result["data"].(map[string]any)["me"], "name")
+
+	_, result = postGraphQL(t, server, graphQLRequest{Query: query})
+	assert.Contains(t, result["errors"].([]any)[0].(map[string]any)["message"], "an operation name is required")
+
+	_, result = postGraphQL(t, server, graphQLRequest{Query: query, OperationName: "C"})
+	assert.Contains(t, result["errors"].([]any)[0].(map[string]any)["message"], "operation 'C' does not exist")
+}
+
+func TestMockServe_SupportsGetForQueriesOnly(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	resp, err := http.Get(server.URL + "/graphql?query=" + url.QueryEscape(`{ me { id } }`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/graphql?query=" + url.QueryEscape(`mutation { rename(id: "1", name: "x") { id } }`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestMockServe_RejectsMalformedRequests(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(`not json`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	status, result := postGraphQL(t, server, graphQLRequest{})
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "request is missing a query", result["errors"].([]any)[0].(map[string]any)["message"])
+}
+
+func TestMockServe_AnswersIntrospection(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query: `{ __schema { queryType { name } } __type(name: "Role") { kind enumValues { name } } }`,
+	})
+
+	data := result["data"].(map[string]any)
+	assert.Equal(t, map[string]any{"queryType": map[string]any{"name": "Query"}}, data["__schema"])
+	assert.Equal(t, "ENUM", data["__type"].(map[string]any)["kind"])
+	assert.Len(t, data["__type"].(map[string]any)["enumValues"], 3)
+}
+
+// Run with -race: the introspection data is built by whichever request needs
+// it first.
+func TestMockServe_AnswersConcurrentIntrospection(t *testing.T) {
+	server := newMockTestServer(t, &mockOptions{listLength: 2})
+	body, err := json.Marshal(graphQLRequest{Query: `{ __schema { types { name } } }`})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	statuses := make([]int, 20)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := http.Post(server.URL+"/graphql", "application/json", strings.NewReader(string(body)))
+			if err != nil {
+				return
+			}
+			defer resp.Body.Close()
+			var result map[string]any
+			if json.NewDecoder(resp.Body).Decode(&result) == nil && result["data"] != nil {
+				statuses[i] = resp.StatusCode
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+}
+
+func TestMockServe_AppliesFixtures(t *testing.T) {
+	fixtures := filepath.Join(t.TempDir(), "fixtures.yaml")
+	require.NoError(t, os.WriteFile(fixtures, []byte(`
+Query.me:
+  name: Ada Lovelace
+  friends:
+    - name: Charles
+User.role: ADMIN
+Node.id: fixed
+Query.search:
+  - __typename: Post
+    title: Notes
+`), 0644))
+
+	server := newMockTestServer(t, &mockOptions{listLength: 2, fixtures: fixtures})
+
+	_, result := postGraphQL(t, server, graphQLRequest{
+		Query: `{ me { id name role friends { name role } } search(term: "x") { __typename ... on Post { title } } }`,
+	})
+
+	data := result["data"].(map[string]any)
+	me := data["me"].(map[string]any)
+	assert.Equal(t, "fixed", me["id"])
+	assert.Equal(t, "Ada Lovelace", me["name"])
+	assert.Equal(t, "ADMIN", me["role"])
+	assert.Equal(t, []any{map[string]any{"name": "Charles", "role": "ADMIN"}}, me["friends"])
+	assert.Equal(t, []any{map[string]any{"__typename": "Post", "title": "Notes"}}, data["search"])
+}
+
+func TestNewMockGenerator_ValidatesFixtures(t *testing.T) {
+	schema := loadMockTestSchema(t)
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		fixtures string
+		err      string
+	}{
+		{"bad key", `{"me": 1}`, "fixture key 'me' must be in the form Type.field"},
+		{"unknown type", `{"Usr.name": 1}`, "type 'Usr' does not exist in schema, did you mean 'User'?"},
+		{"unknown field", `{"User.nme": 1}`, "field 'nme' does not exist on type 'User', did you mean 'name'?"},
+		{"not an object", `{"Role.ADMIN": 1}`, "'Role' is not an object or interface (it's a enum)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-")+".json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.fixtures), 0644))
+
+			_, err := newMockGenerator(schema, &mockOptions{fixtures: path})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
@@ -1,39 +1,133 @@
 package cmd
 
+// SourceLocation is where a schema element is defined.
+type SourceLocation struct {
+	File   string `json:"file"` // Schema source, named after its file
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
 type ArgumentInfo struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
 }
 
 type ArgInfo struct {
-	TypeName     string `json:"typeName,omitempty"`
-	FieldName    string `json:"fieldName,omitempty"`
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	DefaultValue string `json:"defaultValue,omitempty"`
-	Description  string `json:"description,omitempty"`
+	TypeName     string          `json:"typeName,omitempty"`
+	FieldName    string          `json:"fieldName,omitempty"`
+	Name         string          `json:"name"`
+	Type         string          `json:"type"`
+	DefaultValue string          `json:"defaultValue,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Location     *SourceLocation `json:"location,omitempty"`
+
+	// Set with --expand
+	Required   bool             `json:"required,omitempty"`   // Non-null without a default value
+	EnumValues []string         `json:"enumValues,omitempty"` // Values of an enum type
+	Recursive  bool             `json:"recursive,omitempty"`  // The input type is already being expanded, so it isn't again
+	Fields     []InputFieldInfo `json:"fields,omitempty"`     // Fields of an input type, expanded recursively
+}
+
+type InputFieldInfo struct {
+	Name         string           `json:"name"`
+	Type         string           `json:"type"`
+	DefaultValue string           `json:"defaultValue,omitempty"`
+	Description  string           `json:"description,omitempty"`
+	Required     bool             `json:"required,omitempty"`   // Non-null without a default value
+	EnumValues   []string         `json:"enumValues,omitempty"` // Values of an enum type
+	Recursive    bool             `json:"recursive,omitempty"`  // The input type is already being expanded, so it isn't again
+	Fields       []InputFieldInfo `json:"fields,omitempty"`     // Fields of an input type, expanded recursively
+}
+
+// TreeNode is a field in gqlx tree, with the fields of its type nested under it.
+type TreeNode struct {
+	Name       string         `json:"name"` // Field name, or "... on Type" for a union member
+	Type       string         `json:"type"`
+	Arguments  []ArgumentInfo `json:"arguments,omitempty"`
+	List       bool           `json:"list,omitempty"`
+	Deprecated bool           `json:"deprecated,omitempty"`
+	Cycle      bool           `json:"cycle,omitempty"`  // The type is already expanded above, so it isn't again
+	Fields     []TreeNode     `json:"fields,omitempty"` // Fields of the type, down to --depth
+}
+
+// SearchHit is a schema element found by gqlx search.
+type SearchHit struct {
+	Kind        string `json:"kind"`     // type, field, argument or value
+	Location    string `json:"location"` // e.g., "Order", "Order.refund", "Mutation.refund.reason", "Status.REFUNDED"
+	Type        string `json:"type"`     // Kind of a type, type of a field or argument, enum of a value
+	Description string `json:"description,omitempty"`
+	Score       int    `json:"score"`
 }
 
 type FieldInfo struct {
-	TypeName     string         `json:"typeName,omitempty"`
-	Name         string         `json:"name"`
-	Arguments    []ArgumentInfo `json:"arguments,omitempty"`
-	Type         string         `json:"type"`
-	DefaultValue string         `json:"defaultValue,omitempty"`
-	Description  string         `json:"description,omitempty"`
+	TypeName     string          `json:"typeName,omitempty"`
+	Name         string          `json:"name"`
+	Arguments    []ArgumentInfo  `json:"arguments,omitempty"`
+	Type         string          `json:"type"`
+	DefaultValue string          `json:"defaultValue,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Location     *SourceLocation `json:"location,omitempty"`
 }
 
 type TypeInfo struct {
-	Name        string `json:"name"`
-	Kind        string `json:"kind"`
-	Description string `json:"description,omitempty"`
+	Name        string          `json:"name"`
+	Kind        string          `json:"kind"`
+	Description string          `json:"description,omitempty"`
+	Location    *SourceLocation `json:"location,omitempty"`
 }
 
 type ReferenceInfo struct {
-	Location    string `json:"location"`              // e.g., "Query.user" or "Query.users.id"
-	Kind        string `json:"kind"`                  // "field" or "argument"
-	Type        string `json:"type"`                  // The full type string e.g., "User!" or "[User!]!"
-	Description string `json:"description,omitempty"` // Description of the field or argument
+	Location    string          `json:"location"`              // e.g., "Query.user", "Query.users.id" or "@auth.role"
+	Kind        string          `json:"kind"`                  // "field", "argument", "input-field", "implements", "union-member" or "directive-argument"
+	Type        string          `json:"type"`                  // The full type string e.g., "User!" or "[User!]!"
+	Description string          `json:"description,omitempty"` // Description of the field or argument
+	Source      *SourceLocation `json:"source,omitempty"`      // Where the referencing element is defined
+}
+
+type StatInfo struct {
+	Section string  `json:"section"`        // e.g., "types", "documentation", "most-fields"
+	Name    string  `json:"name"`           // e.g., "interface", "fields" or a type name in rankings
+	Value   float64 `json:"value"`          // A count, or a percentage when Unit is "%"
+	Unit    string  `json:"unit,omitempty"` // "%" for percentages
+}
+
+type DeprecationInfo struct {
+	Location       string   `json:"location"`                 // e.g., "User.name", "Query.users.first", "Role.ADMIN" or "@auth.role"
+	Kind           string   `json:"kind"`                     // "field", "argument", "input-field" or "enum-value"
+	Reason         string   `json:"reason"`                   // The @deprecated reason
+	RemovalDate    string   `json:"removalDate,omitempty"`    // A YYYY-MM-DD date found in the reason
+	RemovalVersion string   `json:"removalVersion,omitempty"` // A version found in the reason, e.g., "v3" or "2.0"
+	Usages         *int     `json:"usages,omitempty"`         // Number of usages in operations, when --operations is set
+	UsedIn         []string `json:"usedIn,omitempty"`         // Usage locations as "file:line:column"
+}
+
+type CoverageInfo struct {
+	Field  string   `json:"field"`            // e.g., "User.name"
+	Usages int      `json:"usages"`           // Number of selections of the field in operations
+	UsedIn []string `json:"usedIn,omitempty"` // Usage locations as "file:line:column"
+}
+
+type RelayViolation struct {
+	Rule     string          `json:"rule"`               // e.g., "connection-page-info"
+	Element  string          `json:"element"`            // e.g., "UserConnection" or "Query.node"
+	Message  string          `json:"message"`            // What is wrong and what is expected
+	Location *SourceLocation `json:"location,omitempty"` // Where the element is defined
+}
+
+type EntityInfo struct {
+	Name string      `json:"name"`
+	Kind string      `json:"kind"` // "type" or "interface"
+	Keys []EntityKey `json:"keys"`
+}
+
+type EntityKey struct {
+	Fields     string `json:"fields"`     // The @key field set, e.g., "id" or "sku package { id }"
+	Resolvable bool   `json:"resolvable"` // false for @key(resolvable: false) entity references
+}
+
+type CompositionError struct {
+	Code    string `json:"code"`    // e.g., "FIELD_TYPE_MISMATCH"
+	Message string `json:"message"` // Names the type or field and the subgraphs involved
 }
 
 type Location struct {
@@ -52,18 +146,166 @@ type ValidationResult struct {
 	Errors []ValidationError `json:"errors,omitempty"`
 }
 
+type DocumentValidationResult struct {
+	File string `json:"file"` // Document path, relative to the current directory
+	ValidationResult
+}
+
+// SchemaError is an error in the schema, found by gqlx check-schema.
+type SchemaError struct {
+	Message    string          `json:"message"`
+	Location   *SourceLocation `json:"location,omitempty"`
+	Rule       string          `json:"rule"`                 // e.g., "KnownTypeNames"
+	Suggestion string          `json:"suggestion,omitempty"` // The name an unknown name was likely meant to be
+}
+
+type SchemaCheckResult struct {
+	Valid  bool          `json:"valid"`
+	Errors []SchemaError `json:"errors,omitempty"`
+}
+
+// SelectionInfo is a selection set or fragment generated by gqlx select.
+type SelectionInfo struct {
+	Type      string              `json:"type"`
+	Fragment  string              `json:"fragment,omitempty"`
+	Depth     int                 `json:"depth"`
+	Selection string              `json:"selection"` // GraphQL source
+	Variables []SelectionVariable `json:"variables,omitempty"`
+}
+
+// SelectionVariable is a variable a generated selection passes to a required
+// argument, which the operation must declare.
+type SelectionVariable struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Argument string `json:"argument"` // e.g., "Order.items.first"
+}
+
 type PathInfo struct {
-	Path string `json:"path"`
+	Path              string     `json:"path"`                        // e.g., "Query.user(...) -> User.posts -> Post"
+	Target            string     `json:"target"`                      // The type the path reaches
+	Steps             []PathStep `json:"steps"`                       // The fields along the path, from the root type
+	Depth             int        `json:"depth"`                       // Number of steps
+	RequiredArguments []string   `json:"requiredArguments,omitempty"` // Arguments that must be passed along the path, e.g., "Query.user.id"
+	Score             int        `json:"score"`                       // Cost of fetching through the path, lower is better
+	ScoreBreakdown    []PathCost `json:"scoreBreakdown,omitempty"`    // What the score is made of, with --explain
+}
+
+type PathCost struct {
+	Step   string `json:"step"`   // e.g., "Query.user"
+	Reason string `json:"reason"` // e.g., "step", "required argument id", "list", "connection" or "nullable"
+	Cost   int    `json:"cost"`
+}
+
+type PathStep struct {
+	Parent    string         `json:"parent"`              // The type the field is on
+	Field     string         `json:"field"`               // The field name
+	Type      string         `json:"type"`                // The full return type e.g., "[Post!]!"
+	NonNull   bool           `json:"nonNull"`             // Whether the return type is non-null
+	List      bool           `json:"list"`                // Whether the return type is a list
+	Arguments []PathArgument `json:"arguments,omitempty"` // All arguments of the field
 }
 
-type pathStep struct {
-	typeName  string
-	fieldName string
-	hasArgs   bool
+type PathArgument struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Required     bool   `json:"required"`               // Non-null without a default value
+	DefaultValue string `json:"defaultValue,omitempty"` // The default value as GraphQL source
 }
 
 type ValueInfo struct {
-	EnumName    string `json:"enumName,omitempty"`
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	EnumName    string          `json:"enumName,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Location    *SourceLocation `json:"location,omitempty"`
+}
+
+// IntrospectionResult is the result of the standard introspection query.
+type IntrospectionResult struct {
+	Schema IntrospectionSchema `json:"__schema"`
+}
+
+type IntrospectionSchema struct {
+	Description      *string                  `json:"description"`
+	QueryType        *IntrospectionNamedRef   `json:"queryType"`
+	MutationType     *IntrospectionNamedRef   `json:"mutationType"`
+	SubscriptionType *IntrospectionNamedRef   `json:"subscriptionType"`
+	Types            []IntrospectionType      `json:"types"`
+	Directives       []IntrospectionDirective `json:"directives"`
+}
+
+type IntrospectionNamedRef struct {
+	Name string `json:"name"`
+}
+
+type IntrospectionType struct {
+	Kind           string                   `json:"kind"`
+	Name           string                   `json:"name"`
+	Description    *string                  `json:"description"`
+	SpecifiedByURL *string                  `json:"specifiedByURL"`
+	Fields         []IntrospectionField     `json:"fields"`
+	InputFields    []IntrospectionInput     `json:"inputFields"`
+	Interfaces     []IntrospectionTypeRef   `json:"interfaces"`
+	EnumValues     []IntrospectionEnumValue `json:"enumValues"`
+	PossibleTypes  []IntrospectionTypeRef   `json:"possibleTypes"`
+}
+
+type IntrospectionField struct {
+	Name              string               `json:"name"`
+	Description       *string              `json:"description"`
+	Args              []IntrospectionInput `json:"args"`
+	Type              IntrospectionTypeRef `json:"type"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+// IntrospectionInput describes an argument or input object field (__InputValue).
+type IntrospectionInput struct {
+	Name              string               `json:"name"`
+	Description       *string              `json:"description"`
+	Type              IntrospectionTypeRef `json:"type"`
+	DefaultValue      *string              `json:"defaultValue"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type IntrospectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       *string `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+// IntrospectionTypeRef is a (possibly wrapped) reference to a type.
+// Wrapping types (LIST, NON_NULL) have no name and set OfType.
+type IntrospectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   *string               `json:"name"`
+	OfType *IntrospectionTypeRef `json:"ofType"`
+}
+
+type IntrospectionDirective struct {
+	Name         string               `json:"name"`
+	Description  *string              `json:"description"`
+	IsRepeatable bool                 `json:"isRepeatable"`
+	Locations    []string             `json:"locations"`
+	Args         []IntrospectionInput `json:"args"`
+}
+
+// JSONSchema is a JSON Schema (draft 2020-12) document or subschema.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"` // string or []string
+	Enum                 []any                  `json:"enum,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Default              any                    `json:"default,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
 }
@@ -0,0 +1,337 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Operation Walking
+//
+// Commands that work on operation documents (codegen, mock, coverage, ...) need
+// to know which schema fields a selection set actually selects for a given
+// object type. This mirrors the spec's CollectFields algorithm: fragment spreads
+// and inline fragments are expanded when their type condition applies to the
+// object type, and fields with the same response key are merged.
+
+// collectedField is a response key in a selection set along with every field
+// node that contributes to it. Conditional is set when every node is under
+// @skip or @include, on the field or an enclosing fragment, so the key may be
+// missing from the response.
+type collectedField struct {
+	ResponseKey string
+	Definition  *ast.FieldDefinition
+	Fields      []*ast.Field
+	Conditional bool
+}
+
+// SelectionSet returns the merged sub-selections of every field node.
+func (f *collectedField) SelectionSet() ast.SelectionSet {
+	var merged ast.SelectionSet
+	for _, field := range f.Fields {
+		merged = append(merged, field.SelectionSet...)
+	}
+	return merged
+}
+
+// responseKey returns the key a field appears under in the response.
+func responseKey(field *ast.Field) string {
+	if field.Alias != "" {
+		return field.Alias
+	}
+	return field.Name
+}
+
+// typeConditionApplies reports whether a fragment with the given type condition
+// applies to the object type. An empty condition always applies.
+func typeConditionApplies(schema *ast.Schema, condition string, object *ast.Definition) bool {
+	if condition == "" || condition == object.Name {
+		return true
+	}
+	conditionDef := schema.Types[condition]
+	if conditionDef == nil || !conditionDef.IsAbstractType() {
+		return false
+	}
+	return slices.ContainsFunc(schema.GetPossibleTypes(conditionDef), func(def *ast.Definition) bool {
+		return def.Name == object.Name
+	})
+}
+
+// collectFields returns the fields selected on the object type by the
+// selection set, in the order their response keys first appear. Conditional
+// selections (@skip and @include) are always included.
+func collectFields(schema *ast.Schema, selectionSet ast.SelectionSet, object *ast.Definition) []*collectedField {
+	return collectFieldsWithVariables(schema, selectionSet, object, nil)
+}
+
+// isSelectionIncluded evaluates @skip and @include against the variables.
+func isSelectionIncluded(directives ast.DirectiveList, variables map[string]any) bool {
+	if skip := directives.ForName("skip"); skip != nil && skip.ArgumentMap(variables)["if"] == true {
+		return false
+	}
+	if include := directives.ForName("include"); include != nil && include.ArgumentMap(variables)["if"] == false {
+		return false
+	}
+	return true
+}
+
+// hasCondition reports whether a selection has @skip or @include.
+func hasCondition(directives ast.DirectiveList) bool {
+	return directives.ForName("skip") != nil || directives.ForName("include") != nil
+}
+
+// collectFieldsWithVariables is collectFields for executing an operation:
+// when variables is non-nil, selections excluded by @skip or @include are left out.
+func collectFieldsWithVariables(schema *ast.Schema, selectionSet ast.SelectionSet, object *ast.Definition, variables map[string]any) []*collectedField {
+	included := func(directives ast.DirectiveList) bool {
+		return variables == nil || isSelectionIncluded(directives, variables)
+	}
+
+	var result []*collectedField
+	byKey := map[string]*collectedField{}
+	visitedFragments := map[string]bool{}
+
+	var collect func(ast.SelectionSet, bool)
+	collect = func(selections ast.SelectionSet, conditional bool) {
+		for _, selection := range selections {
+			switch sel := selection.(type) {
+			case *ast.Field:
+				if !included(sel.Directives) {
+					continue
+				}
+				fieldConditional := conditional || hasCondition(sel.Directives)
+				key := responseKey(sel)
+				cf := byKey[key]
+				if cf == nil {
+					cf = &collectedField{ResponseKey: key, Definition: sel.Definition, Conditional: fieldConditional}
+					byKey[key] = cf
+					result = append(result, cf)
+				}
+				cf.Fields = append(cf.Fields, sel)
+				cf.Conditional = cf.Conditional && fieldConditional
+			case *ast.InlineFragment:
+				if included(sel.Directives) && typeConditionApplies(schema, sel.TypeCondition, object) {
+					collect(sel.SelectionSet, conditional || hasCondition(sel.Directives))
+				}
+			case *ast.FragmentSpread:
+				if sel.Definition == nil || visitedFragments[sel.Name] || !included(sel.Directives) {
+					continue
+				}
+				visitedFragments[sel.Name] = true
+				if typeConditionApplies(schema, sel.Definition.TypeCondition, object) {
+					collect(sel.Definition.SelectionSet, conditional || hasCondition(sel.Directives))
+				}
+			}
+		}
+	}
+	collect(selectionSet, false)
+
+	return result
+}
+
+// concreteTypes returns the object types a value of the given type can be at
+// runtime: the type itself for objects, or the sorted possible types of an
+// interface or union.
+func concreteTypes(schema *ast.Schema, def *ast.Definition) []*ast.Definition {
+	if !def.IsAbstractType() {
+		return []*ast.Definition{def}
+	}
+	var result []*ast.Definition
+	for _, possible := range schema.GetPossibleTypes(def) {
+		if possible.Kind == ast.Object {
+			result = append(result, possible)
+		}
+	}
+	slices.SortFunc(result, func(a, b *ast.Definition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// operationRootType returns the schema's root type for an operation.
+func operationRootType(schema *ast.Schema, op *ast.OperationDefinition) *ast.Definition {
+	switch op.Operation {
+	case ast.Mutation:
+		return schema.Mutation
+	case ast.Subscription:
+		return schema.Subscription
+	default:
+		return schema.Query
+	}
+}
+
+// loadOperationFile reads and validates an operation document. If the document
+// is invalid, the same diagnostics as the validate command are written to
+// stderr and an error is returned.
+func loadOperationFile(cmd *cobra.Command, schema *ast.Schema, path string) (*ast.QueryDocument, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query file: %w", err)
+	}
+	content := string(bytes)
+
+	doc, result := loadValidQuery(path, content, schema)
+	if !result.Valid {
+		fmt.Fprint(cmd.ErrOrStderr(), formatValidationResultText(result, path, content, schema))
+		return nil, fmt.Errorf("%s is not valid against the schema", path)
+	}
+	return doc, nil
+}
+
+// loadOperationFiles loads and validates every operation document in paths,
+// stopping at the first invalid document.
+func loadOperationFiles(cmd *cobra.Command, schema *ast.Schema, paths []string) ([]*ast.QueryDocument, error) {
+	var docs []*ast.QueryDocument
+	for _, path := range paths {
+		doc, err := loadOperationFile(cmd, schema, path)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// findOperationFiles returns the .graphql and .gql files under path, sorted.
+// path may also be a single file. Schema files are skipped so operations can
+// live next to it.
+func findOperationFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var schemaFiles []string
+	for _, schemaPath := range schemaPaths() {
+		abs, _ := filepath.Abs(schemaPath)
+		schemaFiles = append(schemaFiles, abs)
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(file); ext != ".graphql" && ext != ".gql" {
+			return nil
+		}
+		if abs, _ := filepath.Abs(file); slices.Contains(schemaFiles, abs) {
+			return nil
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read operations: %w", err)
+	}
+
+	slices.Sort(files)
+	return files, nil
+}
+
+// schemaUsages maps a schema element to the places operation documents use
+// it, as "file:line:column". Elements are keyed the same way commands print
+// them: "Type.field", "Type.field.arg", "@directive.arg", "Enum.VALUE" and
+// "Input.field".
+type schemaUsages map[string][]string
+
+// usedIn returns the usages of an element, sorted. Selecting a field through
+// an interface also counts as a usage of the field, and its arguments, on
+// every type that implements the interface.
+func (u schemaUsages) usedIn(schema *ast.Schema, location string) []string {
+	usedIn := slices.Clone(u[location])
+	if typeName, rest, ok := strings.Cut(location, "."); ok {
+		if def := schema.Types[typeName]; def != nil {
+			for _, iface := range def.Interfaces {
+				usedIn = append(usedIn, u[iface+"."+rest]...)
+			}
+		}
+	}
+	slices.Sort(usedIn)
+	return usedIn
+}
+
+// add records every usage in a validated document. Fragments are counted once
+// where they are defined, not at each spread.
+func (u schemaUsages) add(schema *ast.Schema, path string, doc *ast.QueryDocument) {
+	// The walker visits a fragment's selections again at every spread
+	seen := map[string]bool{}
+	record := func(key string, pos *ast.Position) {
+		location := path
+		if pos != nil {
+			location = fmt.Sprintf("%s:%d:%d", path, pos.Line, pos.Column)
+		}
+		if seen[key+" "+location] {
+			return
+		}
+		seen[key+" "+location] = true
+		u[key] = append(u[key], location)
+	}
+
+	events := &validator.Events{}
+	events.OnField(func(_ *validator.Walker, field *ast.Field) {
+		if field.Definition == nil || field.ObjectDefinition == nil || strings.HasPrefix(field.Name, "__") {
+			return
+		}
+		key := field.ObjectDefinition.Name + "." + field.Name
+		record(key, field.Position)
+		for _, arg := range field.Arguments {
+			record(key+"."+arg.Name, arg.Position)
+		}
+	})
+	events.OnDirective(func(_ *validator.Walker, directive *ast.Directive) {
+		for _, arg := range directive.Arguments {
+			record("@"+directive.Name+"."+arg.Name, arg.Position)
+		}
+	})
+	events.OnValue(func(_ *validator.Walker, value *ast.Value) {
+		if value.Definition == nil {
+			return
+		}
+		switch value.Kind {
+		case ast.EnumValue:
+			record(value.Definition.Name+"."+value.Raw, value.Position)
+		case ast.ObjectValue:
+			for _, child := range value.Children {
+				record(value.Definition.Name+"."+child.Name, child.Position)
+			}
+		}
+	})
+
+	validator.Walk(schema, doc, events)
+}
+
+// loadOperationUsages validates every operation document under path and
+// returns where they use the schema.
+func loadOperationUsages(cmd *cobra.Command, schema *ast.Schema, path string) (schemaUsages, error) {
+	files, err := findOperationFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := schemaUsages{}
+	for _, file := range files {
+		doc, err := loadOperationFile(cmd, schema, file)
+		if err != nil {
+			return nil, err
+		}
+		usages.add(schema, file, doc)
+	}
+	return usages, nil
+}
@@ -6,7 +6,9 @@ package cmd
 import (
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/samwightt/gqlx/pkg/render"
@@ -15,20 +17,39 @@ import (
 )
 
 type pathsOptions struct {
-	maxDepth     int
-	fromType     string
-	shortestOnly bool
-	throughType  string
+	maxDepth       int
+	fromType       string
+	fromRoots      bool
+	shortestOnly   bool
+	throughTypes   []string
+	avoidTypes     []string
+	noRequiredArgs bool
+	top            int
+	explain        bool
 }
 
-func formatPathStep(step pathStep) string {
-	if step.hasArgs {
-		return fmt.Sprintf("%s.%s(...)", step.typeName, step.fieldName)
+// Path scoring
+//
+// Every step costs something to fetch, and some cost more: required arguments
+// have to be known up front, lists and connections fan out (connections also
+// need pagination) and nullable fields may not return anything. The weights
+// are deliberately simple so a score can be explained step by step.
+const (
+	pathCostStep             = 1
+	pathCostRequiredArgument = 2
+	pathCostList             = 1
+	pathCostConnection       = 2
+	pathCostNullable         = 1
+)
+
+func formatPathStep(step PathStep) string {
+	if len(step.Arguments) > 0 {
+		return fmt.Sprintf("%s.%s(...)", step.Parent, step.Field)
 	}
-	return fmt.Sprintf("%s.%s", step.typeName, step.fieldName)
+	return fmt.Sprintf("%s.%s", step.Parent, step.Field)
 }
 
-func formatPath(steps []pathStep, targetType string) string {
+func formatPath(steps []PathStep, targetType string) string {
 	if len(steps) == 0 {
 		return targetType
 	}
@@ -41,37 +62,151 @@ func formatPath(steps []pathStep, targetType string) string {
 	return strings.Join(parts, " -> ") + " -> " + targetType
 }
 
+func formatPathCost(c PathCost) string {
+	return fmt.Sprintf("+%d %s: %s", c.Cost, c.Step, c.Reason)
+}
+
 func formatPathText(p PathInfo) string {
-	return p.Path
+	if p.ScoreBreakdown == nil {
+		return p.Path
+	}
+
+	lines := []string{fmt.Sprintf("%s (score %d)", p.Path, p.Score)}
+	for _, c := range p.ScoreBreakdown {
+		lines = append(lines, "  "+formatPathCost(c))
+	}
+	return strings.Join(lines, "\n")
 }
 
 func formatPathsPretty(paths []PathInfo) string {
 	t := makeTable()
 
+	explain := len(paths) > 0 && paths[0].ScoreBreakdown != nil
 	for _, p := range paths {
-		t.Row(p.Path)
+		row := []string{p.Path, strconv.Itoa(p.Depth), strings.Join(p.RequiredArguments, ", "), strconv.Itoa(p.Score)}
+		if explain {
+			row = append(row, strings.Join(slices.Collect(pluck(p.ScoreBreakdown, formatPathCost)), "\n"))
+		}
+		t.Row(row...)
+	}
+	if explain {
+		t.Headers("path", "depth", "required arguments", "score", "breakdown")
+	} else {
+		t.Headers("path", "depth", "required arguments", "score")
 	}
-	t.Headers("path")
 
 	return t.String()
 }
 
-func findPaths(schema *ast.Schema, fromType string, targetType string, maxDepth int) []PathInfo {
+// pathStepCosts returns what a step adds to a path's score.
+func pathStepCosts(step PathStep) []PathCost {
+	name := step.Parent + "." + step.Field
+	costs := []PathCost{{Step: name, Reason: "step", Cost: pathCostStep}}
+	for _, arg := range step.Arguments {
+		if arg.Required {
+			costs = append(costs, PathCost{Step: name, Reason: "required argument " + arg.Name, Cost: pathCostRequiredArgument})
+		}
+	}
+	if strings.HasSuffix(strings.Trim(step.Type, "[]!"), "Connection") {
+		costs = append(costs, PathCost{Step: name, Reason: "connection", Cost: pathCostConnection})
+	} else if step.List {
+		costs = append(costs, PathCost{Step: name, Reason: "list", Cost: pathCostList})
+	}
+	if !step.NonNull {
+		costs = append(costs, PathCost{Step: name, Reason: "nullable", Cost: pathCostNullable})
+	}
+	return costs
+}
+
+// newPathStep describes a field as a step along a path.
+func newPathStep(parent string, field *ast.FieldDefinition) PathStep {
+	step := PathStep{
+		Parent:  parent,
+		Field:   field.Name,
+		Type:    typeToString(field.Type),
+		NonNull: field.Type.NonNull,
+		List:    field.Type.Elem != nil,
+	}
+	for _, arg := range field.Arguments {
+		pathArg := PathArgument{
+			Name:     arg.Name,
+			Type:     typeToString(arg.Type),
+			Required: arg.Type.NonNull && arg.DefaultValue == nil,
+		}
+		if arg.DefaultValue != nil {
+			pathArg.DefaultValue = arg.DefaultValue.String()
+		}
+		step.Arguments = append(step.Arguments, pathArg)
+	}
+	return step
+}
+
+// newPathInfo builds the result for the steps that reach the target type.
+func newPathInfo(steps []PathStep, targetType string) PathInfo {
+	info := PathInfo{
+		Path:   formatPath(steps, targetType),
+		Target: targetType,
+		Steps:  steps,
+		Depth:  len(steps),
+	}
+	for _, step := range steps {
+		for _, arg := range step.Arguments {
+			if arg.Required {
+				info.RequiredArguments = append(info.RequiredArguments, step.Parent+"."+step.Field+"."+arg.Name)
+			}
+		}
+		for _, c := range pathStepCosts(step) {
+			info.Score += c.Cost
+			info.ScoreBreakdown = append(info.ScoreBreakdown, c)
+		}
+	}
+	return info
+}
+
+// passesThrough reports whether a path selects fields of the given types, in
+// that order.
+func (p PathInfo) passesThrough(typeNames []string) bool {
+	next := 0
+	for _, step := range p.Steps {
+		if next < len(typeNames) && step.Parent == typeNames[next] {
+			next++
+		}
+	}
+	return next == len(typeNames)
+}
+
+// rootTypes returns the schema's query, mutation and subscription types, by
+// the names the schema definition gives them.
+func rootTypes(schema *ast.Schema) []string {
+	var roots []string
+	for _, root := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		if root != nil {
+			roots = append(roots, root.Name)
+		}
+	}
+	return roots
+}
+
+// findPaths returns the paths from fromType to targetType that are at most
+// maxDepth fields long. Paths never go through the avoided types.
+func findPaths(schema *ast.Schema, fromType string, targetType string, maxDepth int, avoid []string) []PathInfo {
 	var results []PathInfo
 
 	startType := schema.Types[fromType]
-	if startType == nil {
+	if startType == nil || slices.Contains(avoid, fromType) {
 		return results
 	}
 
 	type searchState struct {
-		steps   []pathStep
-		visited map[string]bool
+		typeName string
+		steps    []PathStep
+		visited  map[string]bool
 	}
 
 	queue := []searchState{{
-		steps:   []pathStep{},
-		visited: map[string]bool{fromType: true},
+		typeName: fromType,
+		steps:    []PathStep{},
+		visited:  map[string]bool{fromType: true},
 	}}
 
 	for len(queue) > 0 {
@@ -82,23 +217,7 @@ func findPaths(schema *ast.Schema, fromType string, targetType string, maxDepth
 			continue
 		}
 
-		// Determine current type we're exploring
-		currentTypeName := fromType
-		if len(current.steps) > 0 {
-			lastStep := current.steps[len(current.steps)-1]
-			// Get the return type of the last field
-			parentType := schema.Types[lastStep.typeName]
-			if parentType != nil {
-				for _, f := range parentType.Fields {
-					if f.Name == lastStep.fieldName {
-						currentTypeName = getBaseTypeName(f.Type)
-						break
-					}
-				}
-			}
-		}
-
-		currentType := schema.Types[currentTypeName]
+		currentType := schema.Types[current.typeName]
 		if currentType == nil {
 			continue
 		}
@@ -106,36 +225,44 @@ func findPaths(schema *ast.Schema, fromType string, targetType string, maxDepth
 		for _, field := range currentType.Fields {
 			fieldReturnType := getBaseTypeName(field.Type)
 
-			newStep := pathStep{
-				typeName:  currentTypeName,
-				fieldName: field.Name,
-				hasArgs:   len(field.Arguments) > 0,
-			}
+			newStep := newPathStep(current.typeName, field)
 
-			newSteps := make([]pathStep, len(current.steps)+1)
+			newSteps := make([]PathStep, len(current.steps)+1)
 			copy(newSteps, current.steps)
 			newSteps[len(current.steps)] = newStep
 
-			// Check if this field returns our target type
-			if fieldReturnType == targetType {
-				results = append(results, PathInfo{
-					Path: formatPath(newSteps, targetType),
-				})
+			// The federation _entities field reaches every entity through the
+			// _Entity union, so continue from each of its members
+			nextTypes := []string{fieldReturnType}
+			if returnTypeDef := schema.Types[fieldReturnType]; returnTypeDef != nil && returnTypeDef.Kind == ast.Union && returnTypeDef.Name == "_Entity" {
+				nextTypes = returnTypeDef.Types
 			}
 
-			// Continue searching if we haven't visited this type and haven't exceeded depth
-			if !current.visited[fieldReturnType] && len(newSteps) < maxDepth {
-				returnTypeDef := schema.Types[fieldReturnType]
-				// Only continue if it's an object type with fields
-				if returnTypeDef != nil && (returnTypeDef.Kind == ast.Object || returnTypeDef.Kind == ast.Interface) && len(returnTypeDef.Fields) > 0 {
-					newVisited := make(map[string]bool)
-					maps.Copy(newVisited, current.visited)
-					newVisited[fieldReturnType] = true
-
-					queue = append(queue, searchState{
-						steps:   newSteps,
-						visited: newVisited,
-					})
+			for _, nextType := range nextTypes {
+				if slices.Contains(avoid, nextType) {
+					continue
+				}
+
+				// Check if this field returns our target type
+				if nextType == targetType {
+					results = append(results, newPathInfo(newSteps, targetType))
+				}
+
+				// Continue searching if we haven't visited this type and haven't exceeded depth
+				if !current.visited[nextType] && len(newSteps) < maxDepth {
+					nextTypeDef := schema.Types[nextType]
+					// Only continue if it's an object type with fields
+					if nextTypeDef != nil && (nextTypeDef.Kind == ast.Object || nextTypeDef.Kind == ast.Interface) && len(nextTypeDef.Fields) > 0 {
+						newVisited := make(map[string]bool)
+						maps.Copy(newVisited, current.visited)
+						newVisited[nextType] = true
+
+						queue = append(queue, searchState{
+							typeName: nextType,
+							steps:    newSteps,
+							visited:  newVisited,
+						})
+					}
 				}
 			}
 		}
@@ -149,37 +276,108 @@ func findPaths(schema *ast.Schema, fromType string, targetType string, maxDepth
 	return results
 }
 
+// findFieldPaths returns the paths from fromType that end by selecting the
+// field on its type. A path from the field's own type is just the field.
+func findFieldPaths(schema *ast.Schema, fromType string, typeName string, field *ast.FieldDefinition, maxDepth int, avoid []string) []PathInfo {
+	var typePaths [][]PathStep
+	if fromType == typeName && !slices.Contains(avoid, fromType) {
+		typePaths = append(typePaths, nil)
+	}
+	for _, p := range findPaths(schema, fromType, typeName, maxDepth, avoid) {
+		typePaths = append(typePaths, p.Steps)
+	}
+
+	target := typeName + "." + field.Name
+	var results []PathInfo
+	for _, steps := range typePaths {
+		steps = append(slices.Clone(steps), newPathStep(typeName, field))
+		info := newPathInfo(steps, target)
+		info.Path = strings.Join(slices.Collect(pluck(steps, formatPathStep)), " -> ")
+		results = append(results, info)
+	}
+	return results
+}
+
 func NewPathsCmd() *cobra.Command {
 	opts := &pathsOptions{
 		maxDepth: 5,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "paths <type>",
+		Use:   "paths <type>|<type.field>",
 		Short: "Lists all paths from Query to a given type.",
 		Args:  cobra.ExactArgs(1),
 		Long: `Lists all possible paths from a root type to reach a given type.
 
-By default, searches from Query. Use --from to start from a different type.
-Use --shortest to only show the shortest path(s).
+By default, searches from the query type. Use --from to start from a
+different type, or --from-roots to search from the query, mutation and
+subscription types (named as in the schema definition). Use --shortest to
+only show the shortest path(s).
 
 For example, if User can be reached via Query.user(id: ID!) or via
-Query.viewer -> Viewer.friends, both paths will be shown.`,
+Query.viewer -> Viewer.friends, both paths will be shown.
+
+The target can also be a field, such as User.email, to find the paths that
+end by selecting it.
+
+Filters:
+  --through    only paths that select fields of the given types, in the
+               order given (repeatable)
+  --avoid      never go through the given type (repeatable)
+  --no-required-args
+               only paths that can be queried without passing any arguments
+               (fields with only optional arguments are allowed)
+
+Ranking:
+  Every path has a score, the cost of fetching through it. Each step costs 1,
+  plus 2 per required argument, 2 if it returns a connection (a *Connection
+  type) or else 1 if it returns a list, and 1 if it is nullable. Paths are
+  listed best first: lowest score, with ties going to the shorter path. Use
+  --top N to only show the N best paths, and --explain to show how each
+  score adds up.
+
+Fields that take arguments are shown with (...). The JSON output has every
+step of a path: the parent type, field, return type (with whether it's
+non-null or a list) and arguments, along with the path's depth and the
+required arguments along it (non-null without a default).
+
+In an Apollo Federation subgraph (see --federation), the gateway can also
+fetch any entity through Query._entities, so paths through it are shown as
+Query._entities(...) -> User.`,
+		Example: `  # Find how to query users
+  gqlx paths User
+
+  # Find how to select a specific field
+  gqlx paths User.email
+
+  # Paths through Organization and then Team, but never through Admin
+  gqlx paths User --through Organization --through Team --avoid Admin
+
+  # Paths from every root type that need no arguments
+  gqlx paths User --from-roots --no-required-args
+
+  # The best 3 ways to fetch a user, with their scores
+  gqlx paths User --top 3 --explain`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runPaths(cmd, args, opts)
 		},
 	}
 
 	cmd.Flags().IntVar(&opts.maxDepth, "max-depth", 5, "Maximum depth to search for paths")
-	cmd.Flags().StringVar(&opts.fromType, "from", "", "Type to start searching from (default: Query)")
+	cmd.Flags().StringVar(&opts.fromType, "from", "", "Type to start searching from (default: the query type)")
+	cmd.Flags().BoolVar(&opts.fromRoots, "from-roots", false, "Search from the query, mutation and subscription types")
 	cmd.Flags().BoolVar(&opts.shortestOnly, "shortest", false, "Only show the shortest path(s)")
-	cmd.Flags().StringVar(&opts.throughType, "through", "", "Only show paths that pass through the given type")
+	cmd.Flags().StringArrayVar(&opts.throughTypes, "through", nil, "Only show paths that pass through the given type (can be specified multiple times, in order)")
+	cmd.Flags().StringArrayVar(&opts.avoidTypes, "avoid", nil, "Exclude paths that pass through the given type (can be specified multiple times)")
+	cmd.Flags().BoolVar(&opts.noRequiredArgs, "no-required-args", false, "Only show paths that need no required arguments")
+	cmd.Flags().IntVar(&opts.top, "top", 0, "Only show the N best paths by score (0 shows every path)")
+	cmd.Flags().BoolVar(&opts.explain, "explain", false, "Show how each path's score adds up")
 
 	return cmd
 }
 
 func runPaths(cmd *cobra.Command, args []string, opts *pathsOptions) error {
-	targetType := args[0]
+	targetType, targetField, _ := strings.Cut(args[0], ".")
 
 	schema, err := loadCliForSchema()
 	if err != nil {
@@ -191,45 +389,106 @@ func runPaths(cmd *cobra.Command, args []string, opts *pathsOptions) error {
 		return err
 	}
 
-	// Validate from type exists
-	fromType := opts.fromType
-	if fromType == "" {
-		fromType = "Query"
+	// Validate target field exists if specified
+	var field *ast.FieldDefinition
+	if targetField != "" {
+		typeDef := schema.Types[targetType]
+		field = typeDef.Fields.ForName(targetField)
+		if field == nil {
+			if suggestion := findClosest(targetField, pluck(typeDef.Fields, func(f *ast.FieldDefinition) string { return f.Name })); suggestion != "" {
+				return fmt.Errorf("field '%s' does not exist on type '%s', did you mean '%s'?", targetField, targetType, suggestion)
+			}
+			return fmt.Errorf("field '%s' does not exist on type '%s'", targetField, targetType)
+		}
 	}
-	if err := validateTypeExists(schema, fromType, "type"); err != nil {
-		return err
+
+	if opts.top < 0 {
+		return fmt.Errorf("--top must be 0 (no limit) or a positive number, got %d", opts.top)
+	}
+
+	// Validate from types exist
+	if opts.fromRoots && opts.fromType != "" {
+		return fmt.Errorf("--from and --from-roots cannot be used together")
+	}
+	var fromTypes []string
+	switch {
+	case opts.fromRoots:
+		fromTypes = rootTypes(schema)
+	case opts.fromType != "":
+		fromTypes = []string{opts.fromType}
+	case schema.Query != nil:
+		fromTypes = []string{schema.Query.Name}
+	default:
+		fromTypes = []string{"Query"}
+	}
+	for _, fromType := range fromTypes {
+		if err := validateTypeExists(schema, fromType, "type"); err != nil {
+			return err
+		}
 	}
 
-	// Validate through type exists if specified
-	if opts.throughType != "" {
-		if err := validateTypeExists(schema, opts.throughType, "type"); err != nil {
+	// Validate through and avoid types exist if specified
+	for _, typeName := range append(slices.Clone(opts.throughTypes), opts.avoidTypes...) {
+		if err := validateTypeExists(schema, typeName, "type"); err != nil {
 			return err
 		}
 	}
 
-	paths := findPaths(schema, fromType, targetType, opts.maxDepth)
+	var paths []PathInfo
+	for _, fromType := range fromTypes {
+		if field != nil {
+			paths = append(paths, findFieldPaths(schema, fromType, targetType, field, opts.maxDepth, opts.avoidTypes)...)
+		} else {
+			paths = append(paths, findPaths(schema, fromType, targetType, opts.maxDepth, opts.avoidTypes)...)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return paths[i].Path < paths[j].Path
+	})
+
+	// Filter to paths through specific types if requested
+	if len(opts.throughTypes) > 0 {
+		paths = filterSlice(paths, func(p PathInfo) bool {
+			return p.passesThrough(opts.throughTypes)
+		})
+	}
 
-	// Filter to paths through specific type if requested
-	if opts.throughType != "" {
+	// Filter to paths without required arguments if requested
+	if opts.noRequiredArgs {
 		paths = filterSlice(paths, func(p PathInfo) bool {
-			return strings.Contains(p.Path, opts.throughType+".")
+			return len(p.RequiredArguments) == 0
 		})
 	}
 
 	// Filter to shortest paths if requested
 	if opts.shortestOnly && len(paths) > 0 {
-		minDepth := len(strings.Split(paths[0].Path, " -> "))
+		minDepth := paths[0].Depth
 		for _, p := range paths {
-			depth := len(strings.Split(p.Path, " -> "))
-			if depth < minDepth {
-				minDepth = depth
-			}
+			minDepth = min(minDepth, p.Depth)
 		}
 		paths = filterSlice(paths, func(p PathInfo) bool {
-			return len(strings.Split(p.Path, " -> ")) == minDepth
+			return p.Depth == minDepth
 		})
 	}
 
+	// Rank by score, best first, keeping paths with the same rank in
+	// alphabetical order
+	sort.SliceStable(paths, func(i, j int) bool {
+		if paths[i].Score != paths[j].Score {
+			return paths[i].Score < paths[j].Score
+		}
+		return paths[i].Depth < paths[j].Depth
+	})
+	if opts.top > 0 {
+		paths = paths[:min(opts.top, len(paths))]
+	}
+
+	if !opts.explain {
+		for i := range paths {
+			paths[i].ScoreBreakdown = nil
+		}
+	}
+
 	if len(paths) == 0 {
 		fmt.Fprintln(cmd.ErrOrStderr(), "No paths found that match the filters.")
 	}
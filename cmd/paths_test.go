@@ -588,3 +588,327 @@ func TestPaths_ThroughFlag_NoMatches(t *testing.T) {
 
 	assert.Len(t, paths, 0)
 }
+
+func TestPaths_FederationEntities(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+		extend type Query {
+			topProducts: [Product]
+		}
+
+		type Product @key(fields: "upc") {
+			upc: String!
+		}
+
+		extend type User @key(fields: "id") {
+			id: ID! @external
+			reviews: [Review]
+		}
+
+		type Review {
+			body: String
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "--federation", "-f", "text", "Review"})
+	require.NoError(t, err)
+	assert.Equal(t, "Query._entities(...) -> User.reviews -> Review\n", stdout)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "--federation", "-f", "text", "Product"})
+	require.NoError(t, err)
+	assert.Equal(t, "Query.topProducts -> Product\nQuery._entities(...) -> Product\n", stdout)
+}
+
+func TestPaths_JSONSteps(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+		type Query {
+			user(id: ID!, locale: String = "en"): User
+		}
+
+		type User {
+			posts(first: Int!): [Post!]!
+		}
+
+		type Post {
+			title: String
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "json", "Post"})
+	require.NoError(t, err)
+
+	type pathArgument struct {
+		Name         string `json:"name"`
+		Type         string `json:"type"`
+		Required     bool   `json:"required"`
+		DefaultValue string `json:"defaultValue"`
+	}
+	type pathStep struct {
+		Parent    string         `json:"parent"`
+		Field     string         `json:"field"`
+		Type      string         `json:"type"`
+		NonNull   bool           `json:"nonNull"`
+		List      bool           `json:"list"`
+		Arguments []pathArgument `json:"arguments"`
+	}
+	type pathInfo struct {
+		Path              string     `json:"path"`
+		Target            string     `json:"target"`
+		Steps             []pathStep `json:"steps"`
+		Depth             int        `json:"depth"`
+		RequiredArguments []string   `json:"requiredArguments"`
+	}
+
+	var paths []pathInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &paths))
+	assert.Equal(t, []pathInfo{{
+		Path:   "Query.user(...) -> User.posts(...) -> Post",
+		Target: "Post",
+		Steps: []pathStep{
+			{Parent: "Query", Field: "user", Type: "User", Arguments: []pathArgument{
+				{Name: "id", Type: "ID!", Required: true},
+				{Name: "locale", Type: "String", DefaultValue: `"en"`},
+			}},
+			{Parent: "User", Field: "posts", Type: "[Post!]!", NonNull: true, List: true, Arguments: []pathArgument{
+				{Name: "first", Type: "Int!", Required: true},
+			}},
+		},
+		Depth:             2,
+		RequiredArguments: []string{"Query.user.id", "User.posts.first"},
+	}}, paths)
+}
+
+func TestPaths_ThroughFlag_MatchesWholeTypeNames(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+		type Query {
+			blogPost: BlogPost
+			post: Post
+		}
+
+		type BlogPost {
+			author: User
+		}
+
+		type Post {
+			author: User
+		}
+
+		type User {
+			id: ID!
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--through", "Post", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "Query.post -> Post.author -> User\n", stdout)
+}
+
+const pathControlsSchema = `
+	schema {
+		query: RootQuery
+		mutation: RootMutation
+	}
+
+	type RootQuery {
+		user(id: ID!): User
+		viewer: Viewer
+		org: Organization
+	}
+
+	type RootMutation {
+		updateUser(id: ID!): User
+	}
+
+	type Viewer {
+		me: User
+		org: Organization
+	}
+
+	type Organization {
+		team: Team
+		admin: Admin
+	}
+
+	type Team {
+		lead: User
+	}
+
+	type Admin {
+		user: User
+	}
+
+	type User {
+		id: ID!
+		email(format: String = "plain"): String
+	}
+`
+
+func TestPaths_AvoidFlag(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--avoid", "Organization", "--avoid", "Viewer", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery.user(...) -> User\n", stdout)
+}
+
+func TestPaths_AvoidFlag_InvalidType(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "--avoid", "Admn", "User"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'Admin'?")
+}
+
+func TestPaths_ThroughFlag_OrderedWaypoints(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--through", "Viewer", "--through", "Team", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery.viewer -> Viewer.org -> Organization.team -> Team.lead -> User\n", stdout)
+
+	// The waypoints must be passed in order
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--through", "Team", "--through", "Viewer", "User"})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "No paths found that match the filters.")
+}
+
+func TestPaths_DefaultsToSchemaQueryType(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--shortest", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery.user(...) -> User\n", stdout)
+}
+
+func TestPaths_FromRoots(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--from-roots", "--shortest", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootMutation.updateUser(...) -> User\nRootQuery.user(...) -> User\n", stdout)
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "--from-roots", "--from", "Viewer", "User"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--from and --from-roots cannot be used together")
+}
+
+func TestPaths_FieldTarget(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--shortest", "User.email"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery.user(...) -> User.email(...)\n", stdout)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "json", "--from", "User", "User.email"})
+	require.NoError(t, err)
+	var paths []struct {
+		Path   string `json:"path"`
+		Target string `json:"target"`
+		Depth  int    `json:"depth"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &paths))
+	require.Len(t, paths, 1)
+	assert.Equal(t, "User.email(...)", paths[0].Path)
+	assert.Equal(t, "User.email", paths[0].Target)
+	assert.Equal(t, 1, paths[0].Depth)
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "User.emial"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field 'emial' does not exist on type 'User', did you mean 'email'?")
+}
+
+func TestPaths_NoRequiredArgs(t *testing.T) {
+	schemaPath := writeTestSchema(t, pathControlsSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--from-roots", "--no-required-args", "--shortest", "User.email"})
+	require.NoError(t, err)
+	assert.Equal(t, "RootQuery.viewer -> Viewer.me -> User.email(...)\n", stdout)
+}
+
+func TestPaths_TopRanksByScore(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+		type Query {
+			user(id: ID!): User
+			viewer: Viewer!
+			users(first: Int): UserConnection
+		}
+
+		type Viewer {
+			me: User!
+		}
+
+		type UserConnection {
+			nodes: [User]
+		}
+
+		type User {
+			id: ID!
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--top", "2", "User"})
+	require.NoError(t, err)
+	// viewer -> me scores 2, user(id:) scores 4 and users -> nodes scores 7
+	assert.Equal(t, "Query.viewer -> Viewer.me -> User\nQuery.user(...) -> User\n", stdout)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, "Query.viewer -> Viewer.me -> User\nQuery.user(...) -> User\nQuery.users(...) -> UserConnection.nodes -> User\n", stdout, "paths are ranked without --top too")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "--top", "-1", "User"})
+	require.EqualError(t, err, "--top must be 0 (no limit) or a positive number, got -1")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "text", "--explain", "--top", "3", "User"})
+	require.NoError(t, err)
+	assert.Equal(t, `Query.viewer -> Viewer.me -> User (score 2)
+  +1 Query.viewer: step
+  +1 Viewer.me: step
+Query.user(...) -> User (score 4)
+  +1 Query.user: step
+  +2 Query.user: required argument id
+  +1 Query.user: nullable
+Query.users(...) -> UserConnection.nodes -> User (score 7)
+  +1 Query.users: step
+  +2 Query.users: connection
+  +1 Query.users: nullable
+  +1 UserConnection.nodes: step
+  +1 UserConnection.nodes: list
+  +1 UserConnection.nodes: nullable
+`, stdout)
+}
+
+func TestPaths_ExplainJSON(t *testing.T) {
+	schemaPath := writeTestSchema(t, `
+		type Query {
+			user(id: ID!): User
+		}
+
+		type User {
+			id: ID!
+		}
+	`)
+
+	type pathCost struct {
+		Step   string `json:"step"`
+		Reason string `json:"reason"`
+		Cost   int    `json:"cost"`
+	}
+	type pathInfo struct {
+		Score          int        `json:"score"`
+		ScoreBreakdown []pathCost `json:"scoreBreakdown"`
+	}
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "json", "User"})
+	require.NoError(t, err)
+	var paths []pathInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &paths))
+	assert.Equal(t, []pathInfo{{Score: 4}}, paths)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"paths", "-s", schemaPath, "-f", "json", "--explain", "User"})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(stdout), &paths))
+	assert.Equal(t, []pathInfo{{Score: 4, ScoreBreakdown: []pathCost{
+		{Step: "Query.user", Reason: "step", Cost: 1},
+		{Step: "Query.user", Reason: "required argument id", Cost: 2},
+		{Step: "Query.user", Reason: "nullable", Cost: 1},
+	}}}, paths)
+}
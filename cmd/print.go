@@ -0,0 +1,242 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+type printOptions struct {
+	types    typesOptions
+	sort     string
+	builtins bool
+	indent   int
+}
+
+const (
+	printSortAlpha = "alpha"
+	printSortKind  = "kind"
+)
+
+// printKindOrder is the order definitions are grouped in by --sort kind.
+var printKindOrder = []ast.DefinitionKind{
+	ast.Scalar,
+	ast.Interface,
+	ast.Object,
+	ast.Union,
+	ast.Enum,
+	ast.InputObject,
+}
+
+// comparePositions orders two schema positions by source then by offset in the
+// source. Definitions without a position sort last.
+func comparePositions(a, b *ast.Position) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+	if a.Src != nil && b.Src != nil && a.Src.Name != b.Src.Name {
+		return strings.Compare(a.Src.Name, b.Src.Name)
+	}
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+	return a.Column - b.Column
+}
+
+// sortDefinitions orders type definitions in place according to the sort mode.
+func sortDefinitions(defs []*ast.Definition, mode string) {
+	switch mode {
+	case printSortKind:
+		slices.SortStableFunc(defs, func(a, b *ast.Definition) int {
+			if ai, bi := slices.Index(printKindOrder, a.Kind), slices.Index(printKindOrder, b.Kind); ai != bi {
+				return ai - bi
+			}
+			if c := comparePositions(a.Position, b.Position); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+	default:
+		slices.SortFunc(defs, func(a, b *ast.Definition) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+	}
+}
+
+// sortDirectiveDefinitions returns the schema's directive definitions ordered
+// according to the sort mode.
+func sortDirectiveDefinitions(directives map[string]*ast.DirectiveDefinition, mode string) ast.DirectiveDefinitionList {
+	list := slices.Collect(maps.Values(directives))
+	slices.SortFunc(list, func(a, b *ast.DirectiveDefinition) int {
+		if mode == printSortKind {
+			if c := comparePositions(a.Position, b.Position); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return list
+}
+
+// schemaDefinitionFor returns a schema definition block for the schema's root
+// operation types, or nil when every root uses its default name and the block
+// can be omitted.
+func schemaDefinitionFor(schema *ast.Schema) *ast.SchemaDefinition {
+	roots := []struct {
+		operation ast.Operation
+		def       *ast.Definition
+		name      string
+	}{
+		{ast.Query, schema.Query, "Query"},
+		{ast.Mutation, schema.Mutation, "Mutation"},
+		{ast.Subscription, schema.Subscription, "Subscription"},
+	}
+
+	needed := false
+	def := &ast.SchemaDefinition{Directives: schema.SchemaDirectives}
+	for _, root := range roots {
+		if root.def == nil {
+			continue
+		}
+		if root.def.Name != root.name {
+			needed = true
+		}
+		def.OperationTypes = append(def.OperationTypes, &ast.OperationTypeDefinition{
+			Operation: root.operation,
+			Type:      root.def.Name,
+		})
+	}
+	if !needed && len(schema.SchemaDirectives) == 0 {
+		return nil
+	}
+	return def
+}
+
+// printSchema renders the given type definitions of the schema as SDL, with a
+// blank line between definitions. The schema's root operation types and
+// directive definitions are only included when defs covers every type, since
+// they are meaningless for a filtered subset.
+func printSchema(schema *ast.Schema, defs []*ast.Definition, opts *printOptions) string {
+	formatterOpts := []formatter.FormatterOption{
+		formatter.WithIndent(strings.Repeat(" ", opts.indent)),
+	}
+	if opts.builtins {
+		formatterOpts = append(formatterOpts, formatter.WithBuiltin())
+	}
+
+	var chunks []string
+	format := func(doc *ast.SchemaDocument) {
+		var buf bytes.Buffer
+		formatter.NewFormatter(&buf, formatterOpts...).FormatSchemaDocument(doc)
+		if buf.Len() > 0 {
+			chunks = append(chunks, buf.String())
+		}
+	}
+
+	if len(defs) == len(schema.Types) {
+		if schemaDef := schemaDefinitionFor(schema); schemaDef != nil {
+			format(&ast.SchemaDocument{Schema: ast.SchemaDefinitionList{schemaDef}})
+		}
+		for _, directive := range sortDirectiveDefinitions(schema.Directives, opts.sort) {
+			format(&ast.SchemaDocument{Directives: ast.DirectiveDefinitionList{directive}})
+		}
+	}
+	for _, def := range defs {
+		format(&ast.SchemaDocument{Definitions: ast.DefinitionList{def}})
+	}
+
+	return strings.Join(chunks, "\n")
+}
+
+func NewPrintCmd() *cobra.Command {
+	opts := &printOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: "Prints the schema as canonical SDL",
+		Long: `Prints the loaded schema as normalised SDL, keeping descriptions and directives.
+
+The output is stable for a given schema, which makes it useful for review diffs:
+formatting, comments, and type extensions are normalised away.
+
+The types filters (--kind, --implements, --used-by, --name, ...) can be used to
+print only a subset of the schema. Filtered output leaves out the schema
+definition and directive definitions, and may reference types that are not
+printed, so only unfiltered output is guaranteed to parse as a schema.
+
+Sort modes:
+  alpha   All definitions sorted by name (default)
+  kind    Definitions grouped by kind (scalars, interfaces, types, unions,
+          enums, inputs), keeping their original order within each group
+
+Built-in scalars, directives and introspection types are left out by default.
+Use --builtins to include them; the output then no longer re-parses, since
+those definitions are always predeclared.
+
+The --format flag is ignored; output is always SDL.`,
+		Example: `  # Print a canonical version of the schema
+  gqlx print > schema.normalized.graphql
+
+  # Diff two versions of a schema without formatting noise
+  diff <(gqlx print -s old.graphql) <(gqlx print -s new.graphql)
+
+  # Print types grouped by kind, in source order
+  gqlx print --sort kind
+
+  # Print only the enums
+  gqlx print --kind enum
+
+  # Print everything reachable from Query's fields
+  gqlx print --used-by Query`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPrint(cmd, args, opts)
+		},
+	}
+
+	addTypesFilterFlags(cmd, &opts.types)
+	cmd.Flags().StringVar(&opts.sort, "sort", printSortAlpha, "Sort mode: alpha or kind")
+	cmd.Flags().BoolVar(&opts.builtins, "builtins", false, "Include built-in scalars, directives and introspection types")
+	cmd.Flags().IntVar(&opts.indent, "indent", 2, "Number of spaces to indent fields with")
+
+	return cmd
+}
+
+func runPrint(cmd *cobra.Command, args []string, opts *printOptions) error {
+	if opts.sort != printSortAlpha && opts.sort != printSortKind {
+		return fmt.Errorf("--sort must be '%s' or '%s', got '%s'", printSortAlpha, printSortKind, opts.sort)
+	}
+	if opts.indent < 0 {
+		return fmt.Errorf("--indent must not be negative")
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	defs, err := filterTypes(schema, &opts.types)
+	if err != nil {
+		return err
+	}
+	sortDefinitions(defs, opts.sort)
+
+	fmt.Fprint(cmd.OutOrStdout(), printSchema(schema, defs, opts))
+	return nil
+}
@@ -0,0 +1,208 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gqlparser "github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+const printTestSchema = `
+"""
+The root query type
+"""
+type Root {
+	"Get a user"
+	user(id: ID!, "Max results" limit: Int = 10): User @deprecated(reason: "Use node")
+	search(term: String!): [SearchResult!]!
+}
+
+schema {
+	query: Root
+}
+
+directive @auth(role: Role = ADMIN) on FIELD_DEFINITION | OBJECT
+
+enum Role {
+	ADMIN
+	MEMBER @deprecated
+}
+
+union SearchResult = User | Post
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node @auth {
+	id: ID!
+	name: String
+}
+
+type Post implements Node {
+	id: ID!
+}
+
+input UserInput {
+	name: String! = "anonymous"
+}
+
+scalar Date @specifiedBy(url: "https://example.com/date")
+
+extend type User {
+	created: Date
+}
+`
+
+func setupPrintTestSchema(t *testing.T, schema string) string {
+	t.Helper()
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	err := os.WriteFile(schemaPath, []byte(schema), 0644)
+	require.NoError(t, err)
+	return schemaPath
+}
+
+// canonicalSDL formats a schema with gqlparser's own formatter so that two
+// schemas can be compared for equivalence.
+func canonicalSDL(t *testing.T, schema *ast.Schema) string {
+	t.Helper()
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf).FormatSchema(schema)
+	return buf.String()
+}
+
+func TestPrint_RoundTrip(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	for _, sortMode := range []string{"alpha", "kind"} {
+		t.Run(sortMode, func(t *testing.T) {
+			stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--sort", sortMode})
+			require.NoError(t, err)
+
+			original, err := gqlparser.LoadSchema(&ast.Source{Name: "original.graphql", Input: printTestSchema})
+			require.NoError(t, err)
+			printed, err := gqlparser.LoadSchema(&ast.Source{Name: "printed.graphql", Input: stdout})
+			require.NoError(t, err, "printed SDL should re-parse:\n%s", stdout)
+
+			assert.Equal(t, canonicalSDL(t, original), canonicalSDL(t, printed))
+		})
+	}
+}
+
+func TestPrint_KeepsDescriptionsAndDirectives(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "The root query type")
+	assert.Contains(t, stdout, "Get a user")
+	assert.Contains(t, stdout, `@deprecated(reason: "Use node")`)
+	assert.Contains(t, stdout, "type User implements Node @auth {")
+	assert.Contains(t, stdout, "directive @auth(role: Role = ADMIN) on FIELD_DEFINITION | OBJECT")
+	assert.Contains(t, stdout, "schema {\n  query: Root\n}")
+}
+
+func TestPrint_MergesExtensions(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.NotContains(t, stdout, "extend")
+	assert.Contains(t, stdout, "  name: String\n  created: Date\n")
+}
+
+func TestPrint_SortAlpha(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath})
+	require.NoError(t, err)
+
+	order := []string{"scalar Date", "interface Node", "type Post", "enum Role", "type Root", "union SearchResult", "type User", "input UserInput"}
+	assertInOrder(t, stdout, order)
+}
+
+func TestPrint_SortKind(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--sort", "kind"})
+	require.NoError(t, err)
+
+	// Grouped by kind, original order within each kind
+	order := []string{"scalar Date", "interface Node", "type Root", "type User", "type Post", "union SearchResult", "enum Role", "input UserInput"}
+	assertInOrder(t, stdout, order)
+}
+
+func assertInOrder(t *testing.T, output string, items []string) {
+	t.Helper()
+	last := -1
+	for _, item := range items {
+		idx := strings.Index(output, item)
+		require.NotEqual(t, -1, idx, "expected output to contain %q", item)
+		assert.Greater(t, idx, last, "expected %q to come after the previous item", item)
+		last = idx
+	}
+}
+
+func TestPrint_InvalidSort(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--sort", "random"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "--sort must be")
+}
+
+func TestPrint_OmitsBuiltinsByDefault(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath})
+	require.NoError(t, err)
+
+	assert.NotContains(t, stdout, "scalar String")
+	assert.NotContains(t, stdout, "__Type")
+	assert.NotContains(t, stdout, "directive @skip")
+}
+
+func TestPrint_IncludeBuiltins(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--builtins"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "scalar String")
+	assert.Contains(t, stdout, "type __Type")
+	assert.Contains(t, stdout, "directive @skip")
+}
+
+func TestPrint_WithTypesFilters(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--implements", "Node"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "type User implements Node")
+	assert.Contains(t, stdout, "type Post implements Node")
+	assert.NotContains(t, stdout, "type Root")
+	assert.NotContains(t, stdout, "enum Role")
+	// Schema-level definitions are left out of filtered output
+	assert.NotContains(t, stdout, "schema {")
+	assert.NotContains(t, stdout, "directive @auth")
+}
+
+func TestPrint_FilterErrors(t *testing.T) {
+	schemaPath := setupPrintTestSchema(t, printTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"print", "-s", schemaPath, "--used-by", "Rooot"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "did you mean 'Root'")
+}
@@ -5,11 +5,13 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/samwightt/gqlx/pkg/render"
 	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 type referencesOptions struct {
@@ -17,12 +19,38 @@ type referencesOptions struct {
 	inType string
 }
 
+// Reference kinds reported by the references command.
+const (
+	refKindField             = "field"
+	refKindArgument          = "argument"
+	refKindInputField        = "input-field"
+	refKindImplements        = "implements"
+	refKindUnionMember       = "union-member"
+	refKindDirectiveArgument = "directive-argument"
+)
+
+var validReferenceKinds = []string{
+	refKindField,
+	refKindArgument,
+	refKindInputField,
+	refKindImplements,
+	refKindUnionMember,
+	refKindDirectiveArgument,
+}
+
 func formatReferenceText(ref ReferenceInfo) string {
 	desc := ""
 	if ref.Description != "" {
 		desc = " # " + strings.ReplaceAll(ref.Description, "\n", " ")
 	}
-	return fmt.Sprintf("%s: %s%s", ref.Location, ref.Type, desc)
+	prefix := locationPrefix(ref.Source)
+	switch ref.Kind {
+	case refKindImplements:
+		return fmt.Sprintf("%s%s implements %s%s", prefix, ref.Location, ref.Type, desc)
+	case refKindUnionMember:
+		return fmt.Sprintf("%sunion %s = %s%s", prefix, ref.Location, ref.Type, desc)
+	}
+	return fmt.Sprintf("%s%s: %s%s", prefix, ref.Location, ref.Type, desc)
 }
 
 func formatReferencesPretty(refs []ReferenceInfo) string {
@@ -30,13 +58,104 @@ func formatReferencesPretty(refs []ReferenceInfo) string {
 
 	for _, ref := range refs {
 		desc := strings.ReplaceAll(ref.Description, "\n", " ")
-		t.Row(ref.Location, ref.Kind, ref.Type, desc)
+		t.Row(ref.Location, ref.Kind, ref.Type, desc, formatLocation(ref.Source))
 	}
-	t.Headers("location", "kind", "type", "description")
+	t.Headers("location", "kind", "type", "description", "source")
 
 	return t.String()
 }
 
+// findReferences returns every place in the schema that references the target
+// type. inType limits the search to one type's fields, and kind to one
+// reference kind; both are ignored when empty.
+func findReferences(schema *ast.Schema, targetType string, inType string, kind string) []ReferenceInfo {
+	wantKind := func(k string) bool {
+		return kind == "" || kind == k
+	}
+
+	var refs []ReferenceInfo
+
+	for _, typeDef := range schema.Types {
+		// Skip if --in filter is set and doesn't match
+		if inType != "" && typeDef.Name != inType {
+			continue
+		}
+
+		// Input object fields are reported separately from output fields
+		fieldKind := refKindField
+		if typeDef.Kind == ast.InputObject {
+			fieldKind = refKindInputField
+		}
+
+		for _, field := range typeDef.Fields {
+			// Check field return type
+			if getBaseTypeName(field.Type) == targetType && wantKind(fieldKind) {
+				refs = append(refs, ReferenceInfo{
+					Location:    typeDef.Name + "." + field.Name,
+					Kind:        fieldKind,
+					Type:        typeToString(field.Type),
+					Description: field.Description,
+					Source:      sourceLocation(field.Position),
+				})
+			}
+
+			// Check argument types
+			for _, arg := range field.Arguments {
+				if getBaseTypeName(arg.Type) == targetType && wantKind(refKindArgument) {
+					refs = append(refs, ReferenceInfo{
+						Location:    typeDef.Name + "." + field.Name + "." + arg.Name,
+						Kind:        refKindArgument,
+						Type:        typeToString(arg.Type),
+						Description: arg.Description,
+						Source:      sourceLocation(arg.Position),
+					})
+				}
+			}
+		}
+
+		// Check implemented interfaces
+		if slices.Contains(typeDef.Interfaces, targetType) && wantKind(refKindImplements) {
+			refs = append(refs, ReferenceInfo{
+				Location:    typeDef.Name,
+				Kind:        refKindImplements,
+				Type:        targetType,
+				Description: typeDef.Description,
+				Source:      sourceLocation(typeDef.Position),
+			})
+		}
+
+		// Check union members
+		if typeDef.Kind == ast.Union && slices.Contains(typeDef.Types, targetType) && wantKind(refKindUnionMember) {
+			refs = append(refs, ReferenceInfo{
+				Location:    typeDef.Name,
+				Kind:        refKindUnionMember,
+				Type:        targetType,
+				Description: typeDef.Description,
+				Source:      sourceLocation(typeDef.Position),
+			})
+		}
+	}
+
+	// Directives are not types, so they never match an --in filter
+	if inType == "" && wantKind(refKindDirectiveArgument) {
+		for _, directive := range schema.Directives {
+			for _, arg := range directive.Arguments {
+				if getBaseTypeName(arg.Type) == targetType {
+					refs = append(refs, ReferenceInfo{
+						Location:    "@" + directive.Name + "." + arg.Name,
+						Kind:        refKindDirectiveArgument,
+						Type:        typeToString(arg.Type),
+						Description: arg.Description,
+						Source:      sourceLocation(arg.Position),
+					})
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
 func NewReferencesCmd() *cobra.Command {
 	opts := &referencesOptions{}
 
@@ -44,13 +163,23 @@ func NewReferencesCmd() *cobra.Command {
 		Use:   "references <type>",
 		Short: "Shows where a type is used in the schema",
 		Long: `Shows where a given type is used in the schema - specifically which fields
-return it and which arguments use it.
+return it, which arguments and input fields use it, which types implement it
+(for interfaces), which unions include it, and which directive arguments use it.
+
+Reference kinds:
+  field               an object or interface field returns the type
+  argument            a field argument has the type
+  input-field         an input object field has the type
+  implements          a type implements the interface
+  union-member        a union includes the type as a member
+  directive-argument  a directive argument has the type
 
 This is useful for understanding the impact of changes to a type, finding
 all entry points to a type, or exploring the schema structure.
 
 Output formats:
-  text    "Query.user: User", "Query.search.userId: ID!", etc. (default when piping)
+  text    "Query.user: User", "Query.search.userId: ID!", "User implements Node",
+          "union SearchResult = User", etc. (default when piping)
   json    [{"location": "Query.user", "kind": "field", "type": "User"}, ...]
   pretty  Formatted table with columns (default in terminal)`,
 		Example: `  # Find all references to the User type
@@ -62,6 +191,12 @@ Output formats:
   # Find only arguments of type User
   gqlx references User --kind argument
 
+  # Find all types that implement the Node interface
+  gqlx references Node --kind implements
+
+  # Find the unions that User is a member of
+  gqlx references User --kind union-member
+
   # Find references to User only within the Query type
   gqlx references User --in Query
 
@@ -94,7 +229,7 @@ Output formats:
 		},
 	}
 
-	cmd.Flags().StringVar(&opts.kind, "kind", "", "Filter by reference kind: 'field' or 'argument'")
+	cmd.Flags().StringVar(&opts.kind, "kind", "", "Filter by reference kind: "+strings.Join(validReferenceKinds, ", "))
 	cmd.Flags().StringVar(&opts.inType, "in", "", "Only show references from the specified type")
 
 	return cmd
@@ -121,46 +256,11 @@ func runReferences(cmd *cobra.Command, args []string, opts *referencesOptions) e
 	}
 
 	// Validate --kind filter
-	if opts.kind != "" && opts.kind != "field" && opts.kind != "argument" {
-		return fmt.Errorf("--kind must be 'field' or 'argument', got '%s'", opts.kind)
+	if opts.kind != "" && !slices.Contains(validReferenceKinds, opts.kind) {
+		return fmt.Errorf("--kind must be one of %s, got '%s'", strings.Join(validReferenceKinds, ", "), opts.kind)
 	}
 
-	var refs []ReferenceInfo
-
-	for _, typeDef := range schema.Types {
-		// Skip if --in filter is set and doesn't match
-		if opts.inType != "" && typeDef.Name != opts.inType {
-			continue
-		}
-
-		for _, field := range typeDef.Fields {
-			// Check field return type
-			if getBaseTypeName(field.Type) == targetType {
-				if opts.kind == "" || opts.kind == "field" {
-					refs = append(refs, ReferenceInfo{
-						Location:    typeDef.Name + "." + field.Name,
-						Kind:        "field",
-						Type:        typeToString(field.Type),
-						Description: field.Description,
-					})
-				}
-			}
-
-			// Check argument types
-			for _, arg := range field.Arguments {
-				if getBaseTypeName(arg.Type) == targetType {
-					if opts.kind == "" || opts.kind == "argument" {
-						refs = append(refs, ReferenceInfo{
-							Location:    typeDef.Name + "." + field.Name + "." + arg.Name,
-							Kind:        "argument",
-							Type:        typeToString(arg.Type),
-							Description: arg.Description,
-						})
-					}
-				}
-			}
-		}
-	}
+	refs := findReferences(schema, targetType, opts.inType, opts.kind)
 
 	if len(refs) == 0 {
 		fmt.Fprintln(cmd.ErrOrStderr(), "No references found.")
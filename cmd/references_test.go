@@ -313,7 +313,8 @@ func TestReferences_InvalidKindFilter(t *testing.T) {
 
 	_, _, err := cmd.ExecuteWithArgs([]string{"references", "User", "-s", schemaPath, "--kind", "invalid"})
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "--kind must be 'field' or 'argument'")
+	assert.Contains(t, err.Error(), "--kind must be one of")
+	assert.Contains(t, err.Error(), "union-member")
 }
 
 func TestReferences_InTypeFilterNonExistent(t *testing.T) {
@@ -479,3 +480,149 @@ func TestReferences_RequiresTypeArgument(t *testing.T) {
 	_, _, err := cmd.ExecuteWithArgs([]string{"references", "-s", schemaPath})
 	assert.Error(t, err)
 }
+
+func TestReferences_ImplementsReferences(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		interface Node {
+			id: ID!
+		}
+
+		interface Entity implements Node {
+			id: ID!
+		}
+
+		type User implements Node & Entity {
+			id: ID!
+		}
+
+		type Query {
+			user: User
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"references", "Node", "-s", schemaPath, "-f", "text", "--kind", "implements"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "User implements Node")
+	assert.Contains(t, stdout, "Entity implements Node")
+}
+
+func TestReferences_UnionMemberReferences(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		type User {
+			id: ID!
+		}
+
+		type Post {
+			id: ID!
+		}
+
+		union SearchResult = User | Post
+
+		type Query {
+			search: [SearchResult!]!
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"references", "User", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "union SearchResult = User")
+	assert.NotContains(t, stdout, "Post")
+}
+
+func TestReferences_InputFieldReferences(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		enum Role {
+			ADMIN
+			MEMBER
+		}
+
+		input CreateUserInput {
+			role: Role!
+		}
+
+		type User {
+			role: Role
+		}
+
+		type Query {
+			user: User
+		}
+
+		type Mutation {
+			createUser(input: CreateUserInput!): User
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"references", "Role", "-s", schemaPath, "-f", "json"})
+	require.NoError(t, err)
+
+	var refs []struct {
+		Location string `json:"location"`
+		Kind     string `json:"kind"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &refs))
+
+	kinds := map[string]string{}
+	for _, ref := range refs {
+		kinds[ref.Location] = ref.Kind
+	}
+	assert.Equal(t, "input-field", kinds["CreateUserInput.role"])
+	assert.Equal(t, "field", kinds["User.role"])
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"references", "Role", "-s", schemaPath, "-f", "text", "--kind", "input-field"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "CreateUserInput.role: Role!")
+	assert.NotContains(t, stdout, "User.role")
+}
+
+func TestReferences_DirectiveArgumentReferences(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		enum Role {
+			ADMIN
+			MEMBER
+		}
+
+		directive @auth("Role required to access the field" requires: Role!) on FIELD_DEFINITION
+
+		type Query {
+			secret: String @auth(requires: ADMIN)
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"references", "Role", "-s", schemaPath, "-f", "text", "--kind", "directive-argument"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "@auth.requires: Role! # Role required to access the field")
+
+	// Directives are not types, so --in never matches them
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"references", "Role", "-s", schemaPath, "-f", "text", "--kind", "directive-argument", "--in", "Query"})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "No references found")
+}
+
+func TestReferences_NewKindsPrettyFormat(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `
+		interface Node {
+			id: ID!
+		}
+
+		type User implements Node {
+			id: ID!
+		}
+
+		union Result = User
+
+		type Query {
+			node: Node
+		}
+	`)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"references", "User", "-s", schemaPath, "-f", "pretty"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "union-member")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"references", "Node", "-s", schemaPath, "-f", "pretty"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "implements")
+}
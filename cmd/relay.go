@@ -0,0 +1,385 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ErrRelayCheckFailed is returned when the schema does not follow the Relay
+// conventions.
+var ErrRelayCheckFailed = errors.New("relay check failed")
+
+type relayCheckOptions struct {
+	bidirectional bool
+	disable       []string
+}
+
+// Rules checked by relay check.
+const (
+	relayRuleNodeInterface       = "node-interface"
+	relayRuleNodeField           = "node-field"
+	relayRuleNodesField          = "nodes-field"
+	relayRuleConnectionEdges     = "connection-edges"
+	relayRuleConnectionPageInfo  = "connection-page-info"
+	relayRuleConnectionArguments = "connection-arguments"
+	relayRuleEdgeNode            = "edge-node"
+	relayRuleEdgeCursor          = "edge-cursor"
+	relayRulePageInfo            = "page-info"
+)
+
+var relayRules = []string{
+	relayRuleNodeInterface,
+	relayRuleNodeField,
+	relayRuleNodesField,
+	relayRuleConnectionEdges,
+	relayRuleConnectionPageInfo,
+	relayRuleConnectionArguments,
+	relayRuleEdgeNode,
+	relayRuleEdgeCursor,
+	relayRulePageInfo,
+}
+
+func formatRelayViolationText(v RelayViolation) string {
+	return fmt.Sprintf("%s%s [%s]", locationPrefix(v.Location), v.Message, v.Rule)
+}
+
+func formatRelayViolationsPretty(violations []RelayViolation) string {
+	t := makeTable()
+
+	for _, v := range violations {
+		t.Row(formatLocation(v.Location), v.Rule, v.Message)
+	}
+	t.Headers("location", "rule", "message")
+
+	return t.String()
+}
+
+// relayChecker checks a schema against the GraphQL Cursor Connections and
+// Global Object Identification specifications.
+type relayChecker struct {
+	schema        *ast.Schema
+	bidirectional bool
+	disabled      []string
+	violations    []RelayViolation
+}
+
+func (c *relayChecker) report(rule, element string, pos *ast.Position, format string, args ...any) {
+	if slices.Contains(c.disabled, rule) {
+		return
+	}
+	c.violations = append(c.violations, RelayViolation{
+		Rule:     rule,
+		Element:  element,
+		Message:  fmt.Sprintf(format, args...),
+		Location: sourceLocation(pos),
+	})
+}
+
+// isCursorType reports whether a type serializes as a string: String, ID or
+// a custom scalar.
+func (c *relayChecker) isCursorType(t *ast.Type) bool {
+	if t.Elem != nil {
+		return false
+	}
+	def := c.schema.Types[t.Name()]
+	return def != nil && def.Kind == ast.Scalar && (!def.BuiltIn || t.Name() == "String" || t.Name() == "ID")
+}
+
+// checkRootField checks that Query has a root field with the given signature.
+func (c *relayChecker) checkRootField(rule, name, argName, argType, returnType string) {
+	signature := fmt.Sprintf("%s(%s: %s): %s", name, argName, argType, returnType)
+	element := "Query." + name
+
+	field := c.schema.Query.Fields.ForName(name)
+	if field == nil {
+		c.report(rule, element, c.schema.Query.Position, "Query must have a %s field", signature)
+		return
+	}
+	if got := typeToString(field.Type); got != returnType {
+		c.report(rule, element, field.Position, "%s must return %s, got %s", element, returnType, got)
+	}
+	if len(field.Arguments) != 1 || field.Arguments[0].Name != argName || typeToString(field.Arguments[0].Type) != argType {
+		c.report(rule, element, field.Position, "%s must take exactly one argument, %s: %s", element, argName, argType)
+	}
+}
+
+func (c *relayChecker) checkNodes() {
+	node := c.schema.Types["Node"]
+	switch {
+	case node == nil:
+		c.report(relayRuleNodeInterface, "Node", nil, "schema must define a Node interface with an id: ID! field")
+	case node.Kind != ast.Interface:
+		c.report(relayRuleNodeInterface, "Node", node.Position, "Node must be an interface (it's a %s)", kindToString(string(node.Kind)))
+	default:
+		if id := node.Fields.ForName("id"); id == nil || typeToString(id.Type) != "ID!" {
+			c.report(relayRuleNodeInterface, "Node", node.Position, "Node must have an id: ID! field")
+		}
+	}
+
+	if c.schema.Query == nil {
+		c.report(relayRuleNodeField, "Query", nil, "schema must have a Query type with node and nodes fields")
+		return
+	}
+	c.checkRootField(relayRuleNodeField, "node", "id", "ID!", "Node")
+	c.checkRootField(relayRuleNodesField, "nodes", "ids", "[ID!]!", "[Node]!")
+}
+
+func (c *relayChecker) checkConnection(def *ast.Definition) *ast.Definition {
+	var edge *ast.Definition
+
+	edges := def.Fields.ForName("edges")
+	switch {
+	case edges == nil:
+		c.report(relayRuleConnectionEdges, def.Name, def.Position, "%s must have an edges field returning a list of edges", def.Name)
+	case edges.Type.Elem == nil || edges.Type.Elem.Elem != nil:
+		c.report(relayRuleConnectionEdges, def.Name+".edges", edges.Position, "%s.edges must return a list of edges, got %s", def.Name, typeToString(edges.Type))
+	default:
+		edge = c.schema.Types[edges.Type.Name()]
+		if edge.Kind != ast.Object {
+			c.report(relayRuleConnectionEdges, def.Name+".edges", edges.Position, "%s.edges must return a list of an object type, but %s is a %s", def.Name, edge.Name, kindToString(string(edge.Kind)))
+			edge = nil
+		}
+	}
+
+	if pageInfo := def.Fields.ForName("pageInfo"); pageInfo == nil {
+		c.report(relayRuleConnectionPageInfo, def.Name, def.Position, "%s must have a pageInfo: PageInfo! field", def.Name)
+	} else if got := typeToString(pageInfo.Type); got != "PageInfo!" {
+		c.report(relayRuleConnectionPageInfo, def.Name+".pageInfo", pageInfo.Position, "%s.pageInfo must return PageInfo!, got %s", def.Name, got)
+	}
+
+	return edge
+}
+
+func (c *relayChecker) checkEdge(def *ast.Definition) {
+	if node := def.Fields.ForName("node"); node == nil {
+		c.report(relayRuleEdgeNode, def.Name, def.Position, "%s must have a node field", def.Name)
+	} else if node.Type.Elem != nil {
+		c.report(relayRuleEdgeNode, def.Name+".node", node.Position, "%s.node must not return a list, got %s", def.Name, typeToString(node.Type))
+	}
+
+	if cursor := def.Fields.ForName("cursor"); cursor == nil {
+		c.report(relayRuleEdgeCursor, def.Name, def.Position, "%s must have a cursor: String! field", def.Name)
+	} else if !c.isCursorType(cursor.Type) {
+		c.report(relayRuleEdgeCursor, def.Name+".cursor", cursor.Position, "%s.cursor must return a type that serializes as a string, such as String!, got %s", def.Name, typeToString(cursor.Type))
+	}
+}
+
+func (c *relayChecker) checkPageInfo() {
+	def := c.schema.Types["PageInfo"]
+	if def == nil {
+		c.report(relayRulePageInfo, "PageInfo", nil, "schema must define a PageInfo type for its connections")
+		return
+	}
+	if def.Kind != ast.Object {
+		c.report(relayRulePageInfo, "PageInfo", def.Position, "PageInfo must be an object type (it's a %s)", kindToString(string(def.Kind)))
+		return
+	}
+
+	for _, name := range []string{"hasPreviousPage", "hasNextPage"} {
+		if field := def.Fields.ForName(name); field == nil {
+			c.report(relayRulePageInfo, "PageInfo", def.Position, "PageInfo is missing the %s: Boolean! field", name)
+		} else if got := typeToString(field.Type); got != "Boolean!" {
+			c.report(relayRulePageInfo, "PageInfo."+name, field.Position, "PageInfo.%s must return Boolean!, got %s", name, got)
+		}
+	}
+	for _, name := range []string{"startCursor", "endCursor"} {
+		if field := def.Fields.ForName(name); field == nil {
+			c.report(relayRulePageInfo, "PageInfo", def.Position, "PageInfo is missing the %s: String field", name)
+		} else if !c.isCursorType(field.Type) {
+			c.report(relayRulePageInfo, "PageInfo."+name, field.Position, "PageInfo.%s must return a type that serializes as a string, such as String, got %s", name, typeToString(field.Type))
+		}
+	}
+}
+
+// checkConnectionArguments checks that a field returning a connection takes
+// the forward (first, after) and/or backward (last, before) pagination
+// arguments.
+func (c *relayChecker) checkConnectionArguments(parent *ast.Definition, field *ast.FieldDefinition) {
+	element := parent.Name + "." + field.Name
+
+	complete := func(count, cursor string) bool {
+		countArg := field.Arguments.ForName(count)
+		cursorArg := field.Arguments.ForName(cursor)
+		if countArg != nil && (countArg.Type.Elem != nil || countArg.Type.Name() != "Int") {
+			c.report(relayRuleConnectionArguments, element+"."+count, countArg.Position, "%s.%s must be an Int, got %s", element, count, typeToString(countArg.Type))
+		}
+		if cursorArg != nil && !c.isCursorType(cursorArg.Type) {
+			c.report(relayRuleConnectionArguments, element+"."+cursor, cursorArg.Position, "%s.%s must be a cursor type such as String, got %s", element, cursor, typeToString(cursorArg.Type))
+		}
+		if (countArg == nil) != (cursorArg == nil) {
+			present, missing := count, cursor
+			if countArg == nil {
+				present, missing = cursor, count
+			}
+			c.report(relayRuleConnectionArguments, element, field.Position, "%s takes %s but not %s", element, present, missing)
+		}
+		return countArg != nil && cursorArg != nil
+	}
+
+	forward := complete("first", "after")
+	backward := complete("last", "before")
+
+	switch {
+	case c.bidirectional && !(forward && backward):
+		c.report(relayRuleConnectionArguments, element, field.Position, "%s must take first, after, last and before arguments", element)
+	case !forward && !backward:
+		c.report(relayRuleConnectionArguments, element, field.Position, "%s must take first and after arguments, or last and before arguments", element)
+	}
+}
+
+func (c *relayChecker) check() []RelayViolation {
+	c.checkNodes()
+
+	var connections []*ast.Definition
+	for _, def := range userTypes(c.schema) {
+		if def.Kind == ast.Object && strings.HasSuffix(def.Name, "Connection") {
+			connections = append(connections, def)
+		}
+	}
+
+	var edges []*ast.Definition
+	for _, connection := range connections {
+		if edge := c.checkConnection(connection); edge != nil && !slices.Contains(edges, edge) {
+			edges = append(edges, edge)
+		}
+	}
+	for _, edge := range edges {
+		c.checkEdge(edge)
+	}
+	if len(connections) > 0 {
+		c.checkPageInfo()
+	}
+
+	for _, def := range userTypes(c.schema) {
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			continue
+		}
+		for _, field := range def.Fields {
+			if slices.Contains(connections, c.schema.Types[field.Type.Name()]) && field.Type.Elem == nil {
+				c.checkConnectionArguments(def, field)
+			}
+		}
+	}
+
+	return c.violations
+}
+
+func NewRelayCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relay",
+		Short: "Checks the schema against the Relay conventions",
+		Long: `Checks the schema against the conventions Relay relies on: the Global
+Object Identification and Cursor Connections specifications.`,
+	}
+
+	cmd.AddCommand(NewRelayCheckCmd())
+
+	return cmd
+}
+
+func NewRelayCheckCmd() *cobra.Command {
+	opts := &relayCheckOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Reports where the schema breaks the Relay conventions",
+		Long: `Reports where the schema breaks the Relay conventions, with the location of
+each violation. Exits with status 1 if there are any.
+
+Rules (skip one with --disable, or disable them for a project in the config
+file under extensions.gqlx.commands."relay check".disable):
+  node-interface        a Node interface with an id: ID! field
+  node-field            a Query.node(id: ID!): Node field
+  nodes-field           a Query.nodes(ids: [ID!]!): [Node]! field
+  connection-edges      *Connection types have an edges field returning a
+                        list of edge objects
+  connection-page-info  *Connection types have a pageInfo: PageInfo! field
+  connection-arguments  fields returning a connection take first and after,
+                        and/or last and before (both with --bidirectional)
+  edge-node             edge types have a node field that is not a list
+  edge-cursor           edge types have a cursor field that serializes as a
+                        string (String, ID or a custom scalar)
+  page-info             PageInfo has hasPreviousPage: Boolean!,
+                        hasNextPage: Boolean!, startCursor and endCursor
+
+Output formats:
+  text    "schema.graphql:12: UserConnection must have a pageInfo: PageInfo! field [connection-page-info]"
+          (default when piping)
+  json    [{"rule": "connection-page-info", "element": "UserConnection", "message": "...",
+          "location": {"file": "schema.graphql", "line": 12, "column": 3}}, ...]
+  pretty  Formatted table with columns (default in terminal)`,
+		Example: `  # Check the schema
+  gqlx relay check
+
+  # Require every connection to paginate both forwards and backwards
+  gqlx relay check --bidirectional
+
+  # Skip the node and nodes field rules
+  gqlx relay check --disable node-field --disable nodes-field
+
+  # Fail a CI job on violations
+  gqlx relay check -f json > relay.json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRelayCheck(cmd, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.bidirectional, "bidirectional", false, "Require connection fields to take both forward and backward pagination arguments")
+	cmd.Flags().StringArrayVar(&opts.disable, "disable", nil, "Skip a rule (can be specified multiple times)")
+
+	return cmd
+}
+
+func runRelayCheck(cmd *cobra.Command, opts *relayCheckOptions) error {
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range opts.disable {
+		if !slices.Contains(relayRules, rule) {
+			if suggestion := findClosest(rule, slices.Values(relayRules)); suggestion != "" {
+				return fmt.Errorf("unknown rule '%s', did you mean '%s'?", rule, suggestion)
+			}
+			return fmt.Errorf("unknown rule '%s', valid rules: %s", rule, strings.Join(relayRules, ", "))
+		}
+	}
+
+	checker := &relayChecker{schema: schema, bidirectional: opts.bidirectional, disabled: opts.disable}
+	violations := checker.check()
+
+	if len(violations) == 0 && outputFormat != render.FormatJSON {
+		fmt.Fprintln(cmd.ErrOrStderr(), "✓ Schema follows the Relay conventions")
+		return nil
+	}
+
+	renderer := render.Renderer[RelayViolation]{
+		Data:         append([]RelayViolation{}, violations...),
+		TextFormat:   formatRelayViolationText,
+		PrettyFormat: formatRelayViolationsPretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+
+	if len(violations) > 0 {
+		// The violations are the error message
+		cmd.SilenceErrors = true
+		return ErrRelayCheckFailed
+	}
+	return nil
+}
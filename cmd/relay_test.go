@@ -0,0 +1,265 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const relayCompliantSchema = `
+scalar Cursor
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	friends(first: Int, after: Cursor, last: Int, before: Cursor): UserConnection!
+}
+
+type UserConnection {
+	edges: [UserEdge]
+	pageInfo: PageInfo!
+}
+
+type UserEdge {
+	node: User
+	cursor: Cursor!
+}
+
+type PageInfo {
+	hasPreviousPage: Boolean!
+	hasNextPage: Boolean!
+	startCursor: String
+	endCursor: String
+}
+
+type Query {
+	node(id: ID!): Node
+	nodes(ids: [ID!]!): [Node]!
+	users(first: Int, after: String): UserConnection
+}
+`
+
+type relayViolation struct {
+	Rule    string `json:"rule"`
+	Element string `json:"element"`
+	Message string `json:"message"`
+}
+
+func runRelayCheck(t *testing.T, schema string, args ...string) ([]relayViolation, error) {
+	t.Helper()
+	schemaPath := setupRefsTestSchema(t, schema)
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"relay", "check", "-s", schemaPath, "-f", "json"}, args...))
+
+	var violations []relayViolation
+	require.NoError(t, json.Unmarshal([]byte(stdout), &violations))
+	return violations, err
+}
+
+func relayMessages(violations []relayViolation) []string {
+	var messages []string
+	for _, v := range violations {
+		messages = append(messages, v.Message)
+	}
+	return messages
+}
+
+func TestRelayCheck_CompliantSchema(t *testing.T) {
+	violations, err := runRelayCheck(t, relayCompliantSchema)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestRelayCheck_CompliantSchemaTextOutput(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, relayCompliantSchema)
+
+	stdout, stderr, err := cmd.ExecuteWithArgs([]string{"relay", "check", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "Schema follows the Relay conventions")
+}
+
+func TestRelayCheck_MissingNodeInterfaceAndRootFields(t *testing.T) {
+	violations, err := runRelayCheck(t, `
+		type Query {
+			node(id: String): String
+		}
+	`)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cmd.ErrRelayCheckFailed))
+
+	assert.Equal(t, []string{
+		"schema must define a Node interface with an id: ID! field",
+		"Query.node must return Node, got String",
+		"Query.node must take exactly one argument, id: ID!",
+		"Query must have a nodes(ids: [ID!]!): [Node]! field",
+	}, relayMessages(violations))
+	assert.Equal(t, "node-interface", violations[0].Rule)
+	assert.Equal(t, "node-field", violations[1].Rule)
+	assert.Equal(t, "nodes-field", violations[3].Rule)
+}
+
+func TestRelayCheck_NodeWithoutID(t *testing.T) {
+	violations, err := runRelayCheck(t, `
+		interface Node {
+			key: String!
+		}
+
+		type Query {
+			node(id: ID!): Node
+			nodes(ids: [ID!]!): [Node]!
+		}
+	`)
+	require.Error(t, err)
+	assert.Equal(t, []string{"Node must have an id: ID! field"}, relayMessages(violations))
+}
+
+func TestRelayCheck_ConnectionsAndEdges(t *testing.T) {
+	violations, err := runRelayCheck(t, `
+		interface Node {
+			id: ID!
+		}
+
+		type Post implements Node {
+			id: ID!
+		}
+
+		type PostConnection {
+			edges: [PostEdge]
+		}
+
+		type PostEdge {
+			node: [Post]
+			cursor: Int
+		}
+
+		type CommentConnection {
+			edges: CommentEdge
+			pageInfo: PageInfo
+		}
+
+		type CommentEdge {
+			cursor: String!
+		}
+
+		type PageInfo {
+			hasNextPage: Boolean!
+			hasPreviousPage: Boolean
+			startCursor: [String]
+		}
+
+		type Query {
+			node(id: ID!): Node
+			nodes(ids: [ID!]!): [Node]!
+			posts(first: Int, after: String): PostConnection
+			comments(last: Int, before: String): CommentConnection
+		}
+	`)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{
+		"CommentConnection.edges must return a list of edges, got CommentEdge",
+		"CommentConnection.pageInfo must return PageInfo!, got PageInfo",
+		"PostConnection must have a pageInfo: PageInfo! field",
+		"PostEdge.node must not return a list, got [Post]",
+		"PostEdge.cursor must return a type that serializes as a string, such as String!, got Int",
+		"PageInfo.hasPreviousPage must return Boolean!, got Boolean",
+		"PageInfo.startCursor must return a type that serializes as a string, such as String, got [String]",
+		"PageInfo is missing the endCursor: String field",
+	}, relayMessages(violations))
+}
+
+func TestRelayCheck_ConnectionArguments(t *testing.T) {
+	violations, err := runRelayCheck(t, `
+		interface Node {
+			id: ID!
+		}
+
+		type User implements Node {
+			id: ID!
+			friends: UserConnection
+			followers(first: Int, before: String): UserConnection
+			posts(first: String, after: Boolean): UserConnection
+		}
+
+		type UserConnection {
+			edges: [UserEdge]
+			pageInfo: PageInfo!
+		}
+
+		type UserEdge {
+			node: User
+			cursor: String!
+		}
+
+		type PageInfo {
+			hasPreviousPage: Boolean!
+			hasNextPage: Boolean!
+			startCursor: String
+			endCursor: String
+		}
+
+		type Query {
+			node(id: ID!): Node
+			nodes(ids: [ID!]!): [Node]!
+		}
+	`)
+	require.Error(t, err)
+
+	assert.Equal(t, []string{
+		"User.friends must take first and after arguments, or last and before arguments",
+		"User.followers takes first but not after",
+		"User.followers takes before but not last",
+		"User.followers must take first and after arguments, or last and before arguments",
+		"User.posts.first must be an Int, got String",
+		"User.posts.after must be a cursor type such as String, got Boolean",
+	}, relayMessages(violations))
+	for _, v := range violations {
+		assert.Equal(t, "connection-arguments", v.Rule)
+	}
+}
+
+func TestRelayCheck_Bidirectional(t *testing.T) {
+	violations, err := runRelayCheck(t, relayCompliantSchema, "--bidirectional")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{
+		"Query.users must take first, after, last and before arguments",
+	}, relayMessages(violations))
+	assert.Equal(t, "Query.users", violations[0].Element)
+}
+
+func TestRelayCheck_ReportsLocations(t *testing.T) {
+	schemaPath := setupRefsTestSchema(t, `interface Node {
+	id: ID!
+}
+
+type Query {
+	node(id: ID!): Node
+	nodes(ids: [ID!]!): [Node]
+}
+`)
+
+	stdout, stderr, err := cmd.ExecuteWithArgs([]string{"relay", "check", "-s", schemaPath, "-f", "text"})
+	require.Error(t, err)
+
+	assert.Equal(t, "schema.graphql:7: Query.nodes must return [Node]!, got [Node] [nodes-field]\n", stdout)
+	// The violations are the error message, so it is not repeated
+	assert.NotContains(t, stderr, "relay check failed")
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"relay", "check", "-s", schemaPath, "-f", "json"})
+	require.Error(t, err)
+	var violations []struct {
+		Location *testLocation `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &violations))
+	require.Len(t, violations, 1)
+	assert.Equal(t, &testLocation{File: "schema.graphql", Line: 7, Column: 2}, violations[0].Location)
+}
@@ -15,6 +15,16 @@ import (
 var (
 	schemaFilePath string
 	outputFormat   render.Format
+	federation     bool
+	subgraphPaths  []string
+	projectName    string
+
+	// Whether stdout is a terminal, which shows schema locations as links
+	hyperlinks bool
+
+	// Set from the config file, see configure
+	activeProject     *project
+	configSchemaPaths []string
 )
 
 func formatFlag() string {
@@ -38,8 +48,10 @@ It's grep for GraphQL schema files.
 Commands usually output lists that are filtered by various flags. You should check the help of
 the command before using it, as there are lots of useful tools.
 
-By default, gqlx tries to read ./schema.graphql in the current directory.
-A different schema file can be specified using -s.
+By default, gqlx reads the schema from the graphql-config file
+(graphql.config.yml, .graphqlrc, ...) in the current directory or a parent,
+and falls back to ./schema.graphql. A different schema file can be specified
+using -s, and a config project using --project.
 
 Output can be formatted as pretty tables (default in terminals), plain text
 (default when piping), or JSON for integration with other tools.`,
@@ -64,11 +76,21 @@ Output can be formatted as pretty tables (default in terminals), plain text
 
 	// Persistent flags
 	cmd.PersistentFlags().StringVarP(&schemaFilePath, "schema", "s", "schema.graphql", "File path of GraphQL schema")
+	cmd.PersistentFlags().StringVar(&projectName, "project", "", "Use this project from the GraphQL config file")
+	cmd.PersistentFlags().StringArrayVar(&subgraphPaths, "subgraph", nil, "Load the supergraph composed from these subgraph files instead of --schema (can be specified multiple times)")
+	cmd.PersistentFlags().BoolVar(&federation, "federation", false, "Load the schema as an Apollo Federation subgraph, adding the federation directives and types it uses")
 
 	var formatStr string
 	cmd.PersistentFlags().StringVarP(&formatStr, "format", "f", formatFlag(), "Output format: json, text, pretty (default: pretty if interactive, text otherwise)")
 
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := configure(cmd); err != nil {
+			return err
+		}
+
+		out, ok := cmd.OutOrStdout().(*os.File)
+		hyperlinks = ok && term.IsTerminal(int(out.Fd()))
+
 		var err error
 		outputFormat, err = render.ParseFormat(formatStr)
 		return err
@@ -82,6 +104,22 @@ Output can be formatted as pretty tables (default in terminals), plain text
 	cmd.AddCommand(NewValuesCmd())
 	cmd.AddCommand(NewReferencesCmd())
 	cmd.AddCommand(NewValidateCmd())
+	cmd.AddCommand(NewPrintCmd())
+	cmd.AddCommand(NewConvertCmd())
+	cmd.AddCommand(NewCodegenCmd())
+	cmd.AddCommand(NewMockCmd())
+	cmd.AddCommand(NewLSPCmd())
+	cmd.AddCommand(NewStatsCmd())
+	cmd.AddCommand(NewDeprecationsCmd())
+	cmd.AddCommand(NewCoverageCmd())
+	cmd.AddCommand(NewRelayCmd())
+	cmd.AddCommand(NewEntitiesCmd())
+	cmd.AddCommand(NewComposeCmd())
+	cmd.AddCommand(NewExampleCmd())
+	cmd.AddCommand(NewSelectCmd())
+	cmd.AddCommand(NewTreeCmd())
+	cmd.AddCommand(NewSearchCmd())
+	cmd.AddCommand(NewCheckSchemaCmd())
 
 	return cmd
 }
@@ -0,0 +1,408 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/agnivade/levenshtein"
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Search
+//
+// gqlx search builds an inverted index over the names and descriptions of the
+// schema's types, fields, arguments and enum values. Names are split into
+// words at camelCase and underscores, so "refundReason" is found by "refund"
+// and "reason". Every term of a query must match an element for it to be a
+// hit; hits are ranked by how well the terms match:
+//
+//	exact word          6
+//	prefix of a word    3 (terms of 3 or more letters)
+//	fuzzy (--fuzzy)     2 (within 1 edit for 4+ letters, 2 for 7+)
+//
+// times 3 for a match in the name and 1 for a match in the description. A
+// phrase in double quotes, or a camelCase term, must match consecutive words
+// exactly. An element whose whole name is the query gets a bonus.
+
+type searchOptions struct {
+	kinds []string
+	limit int
+	fuzzy bool
+}
+
+const (
+	searchKindType     = "type"
+	searchKindField    = "field"
+	searchKindArgument = "argument"
+	searchKindValue    = "value"
+)
+
+var searchKinds = []string{searchKindType, searchKindField, searchKindArgument, searchKindValue}
+
+const (
+	searchExactWeight  = 6
+	searchPrefixWeight = 3
+	searchFuzzyWeight  = 2
+
+	searchNameWeight        = 3
+	searchDescriptionWeight = 1
+
+	searchExactNameBonus = 10
+)
+
+// searchField is the part of an element a word was found in.
+type searchField int
+
+const (
+	searchName searchField = iota
+	searchDescription
+)
+
+// searchPosting is an occurrence of a word in the index.
+type searchPosting struct {
+	doc      int
+	field    searchField
+	position int
+}
+
+// searchIndex is an inverted index from words to the schema elements they
+// occur in.
+type searchIndex struct {
+	docs     []SearchHit
+	names    [][]string
+	postings map[string][]searchPosting
+}
+
+// searchTokens splits text into lowercase words, also splitting camelCase and
+// snake_case names.
+func searchTokens(text string) []string {
+	var tokens []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) {
+		for _, part := range splitWords(word) {
+			tokens = append(tokens, strings.ToLower(part))
+		}
+	}
+	return tokens
+}
+
+// searchTerm is a word, or a phrase of words that must occur consecutively.
+type searchTerm []string
+
+// parseSearchQuery splits a query into terms. Double quotes group words into a
+// phrase; a camelCase word is a phrase of its parts.
+func parseSearchQuery(query string) []searchTerm {
+	var terms []searchTerm
+	for i, part := range strings.Split(query, `"`) {
+		if i%2 == 1 {
+			if tokens := searchTokens(part); len(tokens) > 0 {
+				terms = append(terms, tokens)
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			if tokens := searchTokens(word); len(tokens) > 0 {
+				terms = append(terms, tokens)
+			}
+		}
+	}
+	return terms
+}
+
+func newSearchIndex(schema *ast.Schema) *searchIndex {
+	index := &searchIndex{postings: map[string][]searchPosting{}}
+
+	add := func(hit SearchHit, name string) {
+		doc := len(index.docs)
+		index.docs = append(index.docs, hit)
+		nameTokens := searchTokens(name)
+		index.names = append(index.names, nameTokens)
+		for i, token := range nameTokens {
+			index.postings[token] = append(index.postings[token], searchPosting{doc, searchName, i})
+		}
+		for i, token := range searchTokens(hit.Description) {
+			index.postings[token] = append(index.postings[token], searchPosting{doc, searchDescription, i})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(schema.Types)) {
+		def := schema.Types[name]
+		if def.BuiltIn || strings.HasPrefix(name, "__") {
+			continue
+		}
+		add(SearchHit{Kind: searchKindType, Location: name, Type: kindToString(string(def.Kind)), Description: def.Description}, name)
+
+		for _, field := range def.Fields {
+			if strings.HasPrefix(field.Name, "__") {
+				continue
+			}
+			location := name + "." + field.Name
+			add(SearchHit{Kind: searchKindField, Location: location, Type: typeToString(field.Type), Description: field.Description}, field.Name)
+			for _, arg := range field.Arguments {
+				add(SearchHit{Kind: searchKindArgument, Location: location + "." + arg.Name, Type: typeToString(arg.Type), Description: arg.Description}, arg.Name)
+			}
+		}
+		for _, value := range def.EnumValues {
+			add(SearchHit{Kind: searchKindValue, Location: name + "." + value.Name, Type: name, Description: value.Description}, value.Name)
+		}
+	}
+
+	return index
+}
+
+// maxSearchDistance is how many edits a fuzzy match of a term may be from it.
+func maxSearchDistance(term string) int {
+	switch n := len(term); {
+	case n >= 7:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// wordWeight returns how well a word in the index matches a term, or 0.
+func wordWeight(word, term string, fuzzy bool) int {
+	switch {
+	case word == term:
+		return searchExactWeight
+	case len(term) >= 3 && strings.HasPrefix(word, term):
+		return searchPrefixWeight
+	case fuzzy && maxSearchDistance(term) > 0 && levenshtein.ComputeDistance(word, term) <= maxSearchDistance(term):
+		return searchFuzzyWeight
+	}
+	return 0
+}
+
+func fieldWeight(field searchField) int {
+	if field == searchName {
+		return searchNameWeight
+	}
+	return searchDescriptionWeight
+}
+
+// scoreWord scores every element for a single-word term: the best match in
+// its name plus the best match in its description.
+func (index *searchIndex) scoreWord(term string, fuzzy bool) map[int]int {
+	best := map[searchPosting]int{}
+	for word, postings := range index.postings {
+		weight := wordWeight(word, term, fuzzy)
+		if weight == 0 {
+			continue
+		}
+		for _, p := range postings {
+			key := searchPosting{doc: p.doc, field: p.field}
+			best[key] = max(best[key], weight*fieldWeight(p.field))
+		}
+	}
+
+	scores := map[int]int{}
+	for key, score := range best {
+		scores[key.doc] += score
+	}
+	return scores
+}
+
+// scorePhrase scores every element for a phrase, which must occur exactly
+// and consecutively in its name or description.
+func (index *searchIndex) scorePhrase(phrase searchTerm) map[int]int {
+	occurrences := make([]map[searchPosting]bool, len(phrase))
+	for i, word := range phrase {
+		occurrences[i] = map[searchPosting]bool{}
+		for _, p := range index.postings[word] {
+			occurrences[i][p] = true
+		}
+	}
+
+	found := map[searchPosting]bool{}
+	for _, p := range index.postings[phrase[0]] {
+		match := true
+		for i := 1; i < len(phrase) && match; i++ {
+			match = occurrences[i][searchPosting{p.doc, p.field, p.position + i}]
+		}
+		if match {
+			found[searchPosting{doc: p.doc, field: p.field}] = true
+		}
+	}
+
+	scores := map[int]int{}
+	for key := range found {
+		scores[key.doc] += len(phrase) * searchExactWeight * fieldWeight(key.field)
+	}
+	return scores
+}
+
+// search returns the elements matching every term, best first.
+func (index *searchIndex) search(terms []searchTerm, opts *searchOptions) []SearchHit {
+	var totals map[int]int
+	for _, term := range terms {
+		var scores map[int]int
+		if len(term) == 1 {
+			scores = index.scoreWord(term[0], opts.fuzzy)
+		} else {
+			scores = index.scorePhrase(term)
+		}
+
+		if totals == nil {
+			totals = scores
+			continue
+		}
+		for doc := range totals {
+			if scores[doc] == 0 {
+				delete(totals, doc)
+			} else {
+				totals[doc] += scores[doc]
+			}
+		}
+	}
+
+	var queryWords []string
+	for _, term := range terms {
+		queryWords = append(queryWords, term...)
+	}
+
+	var hits []SearchHit
+	for doc, score := range totals {
+		hit := index.docs[doc]
+		if len(opts.kinds) > 0 && !slices.Contains(opts.kinds, hit.Kind) {
+			continue
+		}
+		if slices.Equal(index.names[doc], queryWords) {
+			score += searchExactNameBonus
+		}
+		hit.Score = score
+		hits = append(hits, hit)
+	}
+
+	// Best first; shorter locations first among equals, as they are usually
+	// the more general element
+	slices.SortFunc(hits, func(a, b SearchHit) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		if len(a.Location) != len(b.Location) {
+			return len(a.Location) - len(b.Location)
+		}
+		return strings.Compare(a.Location, b.Location)
+	})
+
+	if opts.limit > 0 && len(hits) > opts.limit {
+		hits = hits[:opts.limit]
+	}
+	return hits
+}
+
+func formatSearchHitText(hit SearchHit) string {
+	line := hit.Kind + " " + hit.Location
+	if hit.Kind == searchKindType {
+		line += " (" + hit.Type + ")"
+	} else {
+		line += ": " + hit.Type
+	}
+	line += " (score " + strconv.Itoa(hit.Score) + ")"
+	if hit.Description != "" {
+		line += " # " + strings.ReplaceAll(hit.Description, "\n", " ")
+	}
+	return line
+}
+
+func formatSearchHitsPretty(hits []SearchHit) string {
+	t := makeTable()
+	for _, hit := range hits {
+		t.Row(hit.Kind, hit.Location, hit.Type, strconv.Itoa(hit.Score), strings.ReplaceAll(hit.Description, "\n", " "))
+	}
+	t.Headers("kind", "location", "type", "score", "description")
+	return t.String()
+}
+
+func NewSearchCmd() *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search <terms>...",
+		Short: "Searches names and descriptions across the schema",
+		Long: `Searches the names and descriptions of types, fields, arguments and enum
+values, and lists the matches ranked best first.
+
+Names are split into words at camelCase and underscores, so "refund" finds
+refundReason and REFUND_PENDING. Every term must match. Terms also match words
+they are a prefix of; with --fuzzy they match words a typo or two away. Put a
+phrase in double quotes to match its words consecutively, or write it in
+camelCase.
+
+Matches in names rank above matches in descriptions, and an element named
+exactly like the query ranks first.`,
+		Example: `  # Where is the refund stuff?
+  gqlx search refund
+
+  # Only fields and arguments mentioning a phrase
+  gqlx search '"billing address"' --kind field --kind argument
+
+  # Tolerate typos
+  gqlx search refnd --fuzzy`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(cmd, strings.Join(args, " "), opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.kinds, "kind", nil, "Only show hits of this kind: type, field, argument, value (repeatable)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum number of hits to show, 0 for all")
+	cmd.Flags().BoolVar(&opts.fuzzy, "fuzzy", false, "Also match words within one or two typos of a term")
+
+	return cmd
+}
+
+func runSearch(cmd *cobra.Command, query string, opts *searchOptions) error {
+	for _, kind := range opts.kinds {
+		if !slices.Contains(searchKinds, kind) {
+			if suggestion := findClosest(kind, slices.Values(searchKinds)); suggestion != "" {
+				return fmt.Errorf("unknown kind '%s', did you mean '%s'?", kind, suggestion)
+			}
+			return fmt.Errorf("unknown kind '%s' (valid: %s)", kind, strings.Join(searchKinds, ", "))
+		}
+	}
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit must not be negative")
+	}
+
+	terms := parseSearchQuery(query)
+	if len(terms) == 0 {
+		return fmt.Errorf("search query has no words")
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	hits := newSearchIndex(schema).search(terms, opts)
+	if len(hits) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No results found that match the query.")
+	}
+
+	renderer := render.Renderer[SearchHit]{
+		Data:         hits,
+		TextFormat:   formatSearchHitText,
+		PrettyFormat: formatSearchHitsPretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
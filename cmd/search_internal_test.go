@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTokens(t *testing.T) {
+	assert.Equal(t, []string{"refund", "reason"}, searchTokens("refundReason"))
+	assert.Equal(t, []string{"refund", "approved"}, searchTokens("REFUND_APPROVED"))
+	assert.Equal(t, []string{"money", "was", "sent", "back"}, searchTokens("Money was sent back."))
+	assert.Equal(t, []string{"http", "header"}, searchTokens("HTTPHeader"))
+	assert.Equal(t, []string{"user", "ids", "list"}, searchTokens("userIDsList"))
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	assert.Equal(t, []searchTerm{{"refund"}, {"billing", "address"}, {"order", "id"}}, parseSearchQuery(`refund "billing address" orderId`))
+	assert.Empty(t, parseSearchQuery(`- ""`))
+}
@@ -0,0 +1,152 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchTestSchema = `
+type Query {
+	"Look up an order by id"
+	order(id: ID!): Order
+	refunds(status: RefundStatus): [Refund!]!
+}
+
+type Mutation {
+	"Refund an order, fully or partially"
+	refundOrder(orderId: ID!, "Why the customer asked for their money back" reason: String): Refund
+}
+
+"A customer order"
+type Order {
+	id: ID!
+	billingAddress: String
+}
+
+"Money returned to a customer"
+type Refund {
+	id: ID!
+	refundReason: String
+	status: RefundStatus!
+}
+
+enum RefundStatus {
+	PENDING
+	REFUND_APPROVED
+	"Money was sent back"
+	COMPLETED
+}
+`
+
+type searchHit struct {
+	Kind     string `json:"kind"`
+	Location string `json:"location"`
+	Type     string `json:"type"`
+	Score    int    `json:"score"`
+}
+
+func decodeSearch(t *testing.T, args ...string) []searchHit {
+	t.Helper()
+	schemaPath := writeTestSchema(t, searchTestSchema)
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"search", "-s", schemaPath, "-f", "json"}, args...))
+	require.NoError(t, err)
+
+	var hits []searchHit
+	require.NoError(t, json.Unmarshal([]byte(stdout), &hits))
+	return hits
+}
+
+func hitLocations(hits []searchHit) []string {
+	locations := make([]string, len(hits))
+	for i, hit := range hits {
+		locations[i] = hit.Location
+	}
+	return locations
+}
+
+func TestSearch_CamelCaseNamesRanked(t *testing.T) {
+	hits := decodeSearch(t, "refund")
+
+	assert.Equal(t, []string{
+		"Refund",
+		"Mutation.refundOrder",
+		"RefundStatus",
+		"Refund.refundReason",
+		"RefundStatus.REFUND_APPROVED",
+		"Query.refunds",
+	}, hitLocations(hits))
+	assert.Equal(t, searchHit{Kind: "type", Location: "Refund", Type: "type", Score: 28}, hits[0])
+	assert.Equal(t, searchHit{Kind: "field", Location: "Mutation.refundOrder", Type: "Refund", Score: 24}, hits[1])
+}
+
+func TestSearch_EveryTermMustMatch(t *testing.T) {
+	hits := decodeSearch(t, "refund", "reason")
+	assert.Equal(t, []string{"Refund.refundReason"}, hitLocations(hits))
+
+	// A camelCase term is a phrase of its words
+	hits = decodeSearch(t, "refundReason")
+	assert.Equal(t, []string{"Refund.refundReason"}, hitLocations(hits))
+}
+
+func TestSearch_Descriptions(t *testing.T) {
+	hits := decodeSearch(t, "money", "back")
+	assert.ElementsMatch(t, []string{"RefundStatus.COMPLETED", "Mutation.refundOrder.reason"}, hitLocations(hits))
+
+	hits = decodeSearch(t, `"money back"`)
+	assert.Equal(t, []string{"Mutation.refundOrder.reason"}, hitLocations(hits))
+	assert.Equal(t, "argument", hits[0].Kind)
+}
+
+func TestSearch_PrefixAndFuzzy(t *testing.T) {
+	hits := decodeSearch(t, "billing")
+	assert.Equal(t, []string{"Order.billingAddress"}, hitLocations(hits))
+
+	hits = decodeSearch(t, "addr")
+	assert.Equal(t, []string{"Order.billingAddress"}, hitLocations(hits))
+
+	hits = decodeSearch(t, "refnd")
+	assert.Empty(t, hits)
+
+	hits = decodeSearch(t, "refnd", "--fuzzy")
+	assert.Contains(t, hitLocations(hits), "Refund")
+	assert.Contains(t, hitLocations(hits), "Mutation.refundOrder")
+}
+
+func TestSearch_KindAndLimit(t *testing.T) {
+	hits := decodeSearch(t, "refund", "--kind", "value")
+	assert.Equal(t, []string{"RefundStatus.REFUND_APPROVED"}, hitLocations(hits))
+
+	hits = decodeSearch(t, "refund", "--kind", "type", "--kind", "field", "--limit", "2")
+	assert.Equal(t, []string{"Refund", "Mutation.refundOrder"}, hitLocations(hits))
+}
+
+func TestSearch_Text(t *testing.T) {
+	schemaPath := writeTestSchema(t, searchTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"search", "order", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	assert.Equal(t, "type Order (type) (score 34) # A customer order", lines[0])
+	assert.Contains(t, lines, "argument Mutation.refundOrder.orderId: ID! (score 18)")
+}
+
+func TestSearch_Errors(t *testing.T) {
+	schemaPath := writeTestSchema(t, searchTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"search", "refund", "--kind", "feild", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown kind 'feild', did you mean 'field'?")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"search", "-s", schemaPath, "--", "-"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "search query has no words")
+
+	_, stderr, err := cmd.ExecuteWithArgs([]string{"search", "nothing", "-s", schemaPath})
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "No results found that match the query.")
+}
@@ -0,0 +1,293 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+type selectOptions struct {
+	depth             int
+	fragment          string
+	variables         bool
+	includeDeprecated bool
+}
+
+func NewSelectCmd() *cobra.Command {
+	opts := &selectOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "select <type>",
+		Short: "Generates a selection set or fragment for a type",
+		Long: `Generates a selection set with the fields of a type, or a named fragment with
+--fragment, ready to paste into a query.
+
+Scalar and enum fields are selected directly. Object fields are followed down
+to --depth levels of selection sets and left out below that. Interfaces and
+unions select __typename and an inline fragment for each possible type.
+
+Fields with required arguments are skipped, unless --variables is set: then
+each required argument is passed a variable, and the variables to declare in
+the operation are listed in a comment above the selection. Deprecated fields
+are left out unless --include-deprecated is set.
+
+The selection is validated against the schema before it is printed.
+
+Output formats:
+  text, pretty  The selection set or fragment
+  json          {"type", "fragment", "depth", "selection", "variables"}`,
+		Example: `  # All the useful fields of Order, two levels deep
+  gqlx select Order --depth 2
+
+  # A named fragment, including fields that need arguments
+  gqlx select Order --fragment OrderFields --variables
+
+  # Include deprecated fields
+  gqlx select Order --include-deprecated`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSelect(cmd, args[0], opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.depth, "depth", 2, "Levels of nested selection sets to generate")
+	cmd.Flags().StringVar(&opts.fragment, "fragment", "", "Print a fragment with this name instead of a selection set")
+	cmd.Flags().BoolVar(&opts.variables, "variables", false, "Select fields with required arguments, passing them variables")
+	cmd.Flags().BoolVar(&opts.includeDeprecated, "include-deprecated", false, "Include deprecated fields")
+
+	return cmd
+}
+
+// selector builds the selection set for a type.
+type selector struct {
+	schema            *ast.Schema
+	depth             int
+	variables         bool
+	includeDeprecated bool
+
+	// Variables passed to required arguments, with the argument each one is
+	// for, by name
+	definitions ast.VariableDefinitionList
+	arguments   []string
+	used        map[string]bool
+}
+
+func newSelector(schema *ast.Schema, opts *selectOptions) *selector {
+	return &selector{
+		schema:            schema,
+		depth:             opts.depth,
+		variables:         opts.variables,
+		includeDeprecated: opts.includeDeprecated,
+		used:              map[string]bool{},
+	}
+}
+
+// selectionSet selects the fields of a type at the given level of nesting,
+// starting from 1.
+func (s *selector) selectionSet(def *ast.Definition, level int) ast.SelectionSet {
+	if !def.IsAbstractType() {
+		return s.fields(def, def.Fields, level, nil)
+	}
+
+	// Fields with the same response name in different inline fragments must
+	// have the same type, so differing ones are aliased
+	responseTypes := map[string]string{"__typename": "String!"}
+	selectionSet := ast.SelectionSet{&ast.Field{Alias: "__typename", Name: "__typename"}}
+	if def.Kind == ast.Interface {
+		selectionSet = append(selectionSet, s.fields(def, def.Fields, level, responseTypes)...)
+	}
+	for _, possible := range concreteTypes(s.schema, def) {
+		fields := filterSlice(possible.Fields, func(f *ast.FieldDefinition) bool {
+			return def.Fields.ForName(f.Name) == nil
+		})
+		if fragment := s.fields(possible, fields, level, responseTypes); len(fragment) > 0 {
+			selectionSet = append(selectionSet, &ast.InlineFragment{TypeCondition: possible.Name, SelectionSet: fragment})
+		}
+	}
+	return selectionSet
+}
+
+// fields selects the given fields of parent. Object fields below the maximum
+// depth, and fields whose own selection set would be empty, are left out.
+func (s *selector) fields(parent *ast.Definition, fields ast.FieldList, level int, responseTypes map[string]string) ast.SelectionSet {
+	var selectionSet ast.SelectionSet
+	for _, f := range fields {
+		if strings.HasPrefix(f.Name, "__") || (isFieldDeprecated(f) && !s.includeDeprecated) {
+			continue
+		}
+		required := filterSlice(f.Arguments, func(a *ast.ArgumentDefinition) bool {
+			return isRequiredInput(a.Type, a.DefaultValue)
+		})
+		if len(required) > 0 && !s.variables {
+			continue
+		}
+
+		mark := len(s.definitions)
+		field := &ast.Field{Alias: f.Name, Name: f.Name}
+		for _, arg := range required {
+			field.Arguments = append(field.Arguments, &ast.Argument{
+				Name:  arg.Name,
+				Value: &ast.Value{Kind: ast.Variable, Raw: s.variable(parent, f, arg)},
+			})
+		}
+
+		if target := s.schema.Types[f.Type.Name()]; target.Kind != ast.Scalar && target.Kind != ast.Enum {
+			if level < s.depth {
+				field.SelectionSet = s.selectionSet(target, level+1)
+			}
+			if len(field.SelectionSet) == 0 {
+				s.releaseVariables(mark)
+				continue
+			}
+		}
+
+		if responseTypes != nil {
+			typ := typeToString(f.Type)
+			if existing, ok := responseTypes[field.Alias]; ok && existing != typ {
+				field.Alias = strings.ToLower(parent.Name[:1]) + parent.Name[1:] + upperFirst(f.Name)
+			}
+			responseTypes[field.Alias] = typ
+		}
+		selectionSet = append(selectionSet, field)
+	}
+	return selectionSet
+}
+
+// variable declares a variable for a required argument, named after the
+// argument, or the field and argument if the name is taken.
+func (s *selector) variable(parent *ast.Definition, field *ast.FieldDefinition, arg *ast.ArgumentDefinition) string {
+	name := arg.Name
+	if s.used[name] {
+		name = field.Name + upperFirst(arg.Name)
+	}
+	for i, base := 2, name; s.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	s.used[name] = true
+	s.definitions = append(s.definitions, &ast.VariableDefinition{Variable: name, Type: arg.Type})
+	s.arguments = append(s.arguments, parent.Name+"."+field.Name+"."+arg.Name)
+	return name
+}
+
+// releaseVariables forgets the variables declared since mark, for a field that
+// was left out after all.
+func (s *selector) releaseVariables(mark int) {
+	for _, def := range s.definitions[mark:] {
+		delete(s.used, def.Variable)
+	}
+	s.definitions = s.definitions[:mark]
+	s.arguments = s.arguments[:mark]
+}
+
+// formatSelection prints a selection set, or a fragment if name is set.
+func formatSelection(typeName, name string, selectionSet ast.SelectionSet) string {
+	fragment := name
+	if fragment == "" {
+		fragment = "Selection"
+	}
+
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent("  ")).FormatQueryDocument(&ast.QueryDocument{
+		Fragments: ast.FragmentDefinitionList{{Name: fragment, TypeCondition: typeName, SelectionSet: selectionSet}},
+	})
+	source := strings.TrimSpace(buf.String())
+	if name == "" {
+		source = source[strings.Index(source, "{"):]
+	}
+	return source
+}
+
+// validateSelection checks a generated selection with the same validation as
+// gqlx validate, as a fragment on the type. The fragment is unused and its
+// variables are undeclared, which is expected.
+func validateSelection(schema *ast.Schema, typeName string, selectionSet ast.SelectionSet) error {
+	source := formatSelection(typeName, "Selection", selectionSet)
+	result := validateQuery("selection", source, schema)
+	for _, err := range result.Errors {
+		if err.Rule != "NoUnusedFragments" {
+			return fmt.Errorf("generated selection is not valid: %s", err.Message)
+		}
+	}
+	return nil
+}
+
+func formatSelectionText(info SelectionInfo) string {
+	if len(info.Variables) == 0 {
+		return info.Selection
+	}
+	var definitions []string
+	for _, v := range info.Variables {
+		definitions = append(definitions, "$"+v.Name+": "+v.Type)
+	}
+	return fmt.Sprintf("# Variables: (%s)\n%s", strings.Join(definitions, ", "), info.Selection)
+}
+
+func runSelect(cmd *cobra.Command, typeName string, opts *selectOptions) error {
+	if opts.depth < 1 {
+		return fmt.Errorf("--depth must be at least 1")
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	if err := validateTypeExists(schema, typeName, "type"); err != nil {
+		return err
+	}
+	def := schema.Types[typeName]
+	if !slices.Contains([]ast.DefinitionKind{ast.Object, ast.Interface, ast.Union}, def.Kind) {
+		return fmt.Errorf("'%s' has no fields to select (it's a %s)", typeName, kindToString(string(def.Kind)))
+	}
+
+	s := newSelector(schema, opts)
+	selectionSet := s.selectionSet(def, 1)
+	if len(selectionSet) == 0 {
+		hint := ""
+		if !s.variables {
+			hint = ", try --variables"
+		}
+		return fmt.Errorf("'%s' has no fields that can be selected%s", typeName, hint)
+	}
+	if err := validateSelection(schema, typeName, selectionSet); err != nil {
+		return err
+	}
+
+	info := SelectionInfo{
+		Type:      typeName,
+		Fragment:  opts.fragment,
+		Depth:     opts.depth,
+		Selection: formatSelection(typeName, opts.fragment, selectionSet),
+	}
+	for i, def := range s.definitions {
+		info.Variables = append(info.Variables, SelectionVariable{
+			Name:     def.Variable,
+			Type:     typeToString(def.Type),
+			Argument: s.arguments[i],
+		})
+	}
+
+	if outputFormat == render.FormatJSON {
+		bytes, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), formatSelectionText(info))
+	return nil
+}
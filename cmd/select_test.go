@@ -0,0 +1,183 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const selectTestSchema = `
+type Query {
+	order(id: ID!): Order
+	node(id: ID!): Node
+}
+
+interface Node {
+	id: ID!
+}
+
+type Order implements Node {
+	id: ID!
+	status: Status!
+	total: Float @deprecated(reason: "Use amount")
+	amount: Money!
+	customer: Customer
+	items(first: Int!, after: String): [Item!]!
+	result: SearchResult
+}
+
+type Money {
+	value: Int!
+	currency: String!
+}
+
+type Customer implements Node {
+	id: ID!
+	name: String
+}
+
+type Item {
+	sku: String!
+	quantity(unit: String!): Int
+}
+
+type Product {
+	id: ID!
+	value: String
+}
+
+enum Status {
+	OPEN
+	CLOSED
+}
+
+union SearchResult = Money | Product
+`
+
+func TestSelect_SelectionSet(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"select", "Order", "--depth", "2", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  id
+  status
+  amount {
+    value
+    currency
+  }
+  customer {
+    id
+    name
+  }
+  result {
+    __typename
+    ... on Money {
+      value
+      currency
+    }
+    ... on Product {
+      id
+      productValue: value
+    }
+  }
+}
+`, stdout)
+}
+
+func TestSelect_Depth(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"select", "Order", "--depth", "1", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  id\n  status\n}\n", stdout)
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"select", "Order", "--depth", "0", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--depth must be at least 1")
+}
+
+func TestSelect_Deprecated(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"select", "Order", "--depth", "1", "--include-deprecated", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "total")
+}
+
+func TestSelect_InterfaceInlineFragments(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"select", "Node", "--depth", "1", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  __typename
+  id
+  ... on Customer {
+    name
+  }
+  ... on Order {
+    status
+  }
+}
+`, stdout)
+}
+
+func TestSelect_FragmentWithVariablesValidates(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"select", "Order", "--fragment", "OrderFields", "--variables", "-s", schemaPath, "-f", "json"})
+	require.NoError(t, err)
+
+	var info struct {
+		Fragment  string `json:"fragment"`
+		Selection string `json:"selection"`
+		Variables []struct {
+			Name     string `json:"name"`
+			Type     string `json:"type"`
+			Argument string `json:"argument"`
+		} `json:"variables"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &info))
+	assert.Equal(t, "OrderFields", info.Fragment)
+	assert.True(t, strings.HasPrefix(info.Selection, "fragment OrderFields on Order {"))
+	assert.Contains(t, info.Selection, "items(first: $first) {")
+	assert.Contains(t, info.Selection, "quantity(unit: $unit)")
+	require.Len(t, info.Variables, 2)
+	assert.Equal(t, "Order.items.first", info.Variables[0].Argument)
+	assert.Equal(t, "Int!", info.Variables[0].Type)
+	assert.Equal(t, "Item.quantity.unit", info.Variables[1].Argument)
+
+	// The fragment can be used in a query that declares its variables
+	queryPath := filepath.Join(filepath.Dir(schemaPath), "query.graphql")
+	query := `query GetOrder($first: Int!, $unit: String!) { order(id: "1") { ...OrderFields } }` + "\n" + info.Selection
+	require.NoError(t, os.WriteFile(queryPath, []byte(query), 0644))
+	_, _, err = cmd.ExecuteWithArgs([]string{"validate", queryPath, "-s", schemaPath})
+	require.NoError(t, err)
+
+	stdout, _, err = cmd.ExecuteWithArgs([]string{"select", "Order", "--variables", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stdout, "# Variables: ($first: Int!, $unit: String!)\n{\n"))
+}
+
+func TestSelect_Errors(t *testing.T) {
+	schemaPath := writeTestSchema(t, selectTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"select", "Ordr", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "type 'Ordr' does not exist in schema, did you mean 'Order'?")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"select", "Status", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'Status' has no fields to select (it's a enum)")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"select", "Query", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'Query' has no fields that can be selected, try --variables")
+}
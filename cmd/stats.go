@@ -0,0 +1,326 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type statsOptions struct {
+	top int
+}
+
+// Stat sections, in the order they are reported.
+const (
+	statSectionTypes          = "types"
+	statSectionElements       = "elements"
+	statSectionDocumentation  = "documentation"
+	statSectionDeprecated     = "deprecated"
+	statSectionOperations     = "operations"
+	statSectionDepth          = "depth"
+	statSectionMostFields     = "most-fields"
+	statSectionMostReferenced = "most-referenced"
+)
+
+// statKindOrder is the order type kinds are counted in.
+var statKindOrder = []ast.DefinitionKind{ast.Scalar, ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject}
+
+func formatStatValue(stat StatInfo) string {
+	return strconv.FormatFloat(stat.Value, 'f', -1, 64) + stat.Unit
+}
+
+func formatStatText(stat StatInfo) string {
+	return fmt.Sprintf("%s.%s: %s", stat.Section, stat.Name, formatStatValue(stat))
+}
+
+func formatStatsPretty(stats []StatInfo) string {
+	t := makeTable()
+
+	previous := ""
+	for _, stat := range stats {
+		section := stat.Section
+		if section == previous {
+			section = ""
+		}
+		previous = stat.Section
+		t.Row(section, stat.Name, formatStatValue(stat))
+	}
+	t.Headers("section", "name", "value")
+
+	return t.String()
+}
+
+// percentage returns part/total as a percentage rounded to one decimal place.
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+// userTypes returns the types defined by the schema, leaving out built-in
+// scalars and introspection types, sorted by name.
+func userTypes(schema *ast.Schema) []*ast.Definition {
+	var defs []*ast.Definition
+	for _, name := range slices.Sorted(maps.Keys(schema.Types)) {
+		if def := schema.Types[name]; !def.BuiltIn {
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// userFields returns the fields of a type, leaving out the introspection meta
+// fields added to Query.
+func userFields(def *ast.Definition) ast.FieldList {
+	return filterSlice(def.Fields, func(f *ast.FieldDefinition) bool {
+		return !strings.HasPrefix(f.Name, "__")
+	})
+}
+
+// maxDepthFrom returns how many nested selections it takes to reach the most
+// deeply nested type from the root type, following the shortest path to each
+// type.
+func maxDepthFrom(schema *ast.Schema, root *ast.Definition) int {
+	depths := map[string]int{root.Name: 0}
+	queue := []*ast.Definition{root}
+	maxDepth := 0
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, field := range userFields(current) {
+			def := schema.Types[field.Type.Name()]
+			if def == nil || (def.Kind != ast.Object && def.Kind != ast.Interface && def.Kind != ast.Union) {
+				continue
+			}
+			// Selections on interfaces and unions continue on the possible types
+			for _, next := range append([]*ast.Definition{def}, concreteTypes(schema, def)...) {
+				if _, seen := depths[next.Name]; seen {
+					continue
+				}
+				depths[next.Name] = depths[current.Name] + 1
+				maxDepth = max(maxDepth, depths[next.Name])
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return maxDepth
+}
+
+// topStats returns stats for the n types with the highest counts, breaking
+// ties by name.
+func topStats(section string, counts map[string]int, n int) []StatInfo {
+	names := slices.Collect(filterKeys(counts, func(_ string, count int) bool { return count > 0 }))
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts[b], counts[a]), strings.Compare(a, b))
+	})
+
+	var stats []StatInfo
+	for _, name := range names[:min(n, len(names))] {
+		stats = append(stats, StatInfo{Section: section, Name: name, Value: float64(counts[name])})
+	}
+	return stats
+}
+
+// collectStats computes the schema statistics. Built-in scalars, introspection
+// types and built-in directives are not counted.
+func collectStats(schema *ast.Schema, top int) []StatInfo {
+	var stats []StatInfo
+	add := func(section, name string, value int) {
+		stats = append(stats, StatInfo{Section: section, Name: name, Value: float64(value)})
+	}
+
+	defs := userTypes(schema)
+
+	// Types by kind
+	kinds := map[ast.DefinitionKind]int{}
+	for _, def := range defs {
+		kinds[def.Kind]++
+	}
+	for _, kind := range statKindOrder {
+		add(statSectionTypes, kindToString(string(kind)), kinds[kind])
+	}
+	add(statSectionTypes, "total", len(defs))
+
+	// Elements, documentation and deprecations
+	var fields, describedFields, describedTypes, arguments, inputFields, enumValues int
+	var deprecatedFields, deprecatedArguments, deprecatedInputFields, deprecatedEnumValues int
+	fieldCounts := map[string]int{}
+	for _, def := range defs {
+		if def.Description != "" {
+			describedTypes++
+		}
+
+		switch def.Kind {
+		case ast.Object, ast.Interface:
+			for _, field := range userFields(def) {
+				fields++
+				fieldCounts[def.Name]++
+				if field.Description != "" {
+					describedFields++
+				}
+				if isFieldDeprecated(field) {
+					deprecatedFields++
+				}
+				for _, arg := range field.Arguments {
+					arguments++
+					if isArgDeprecated(arg) {
+						deprecatedArguments++
+					}
+				}
+			}
+		case ast.InputObject:
+			for _, field := range def.Fields {
+				inputFields++
+				fieldCounts[def.Name]++
+				if isFieldDeprecated(field) {
+					deprecatedInputFields++
+				}
+			}
+		case ast.Enum:
+			for _, value := range def.EnumValues {
+				enumValues++
+				if isValueDeprecated(value) {
+					deprecatedEnumValues++
+				}
+			}
+		}
+	}
+
+	directives := 0
+	for _, directive := range schema.Directives {
+		if directive.Position == nil || directive.Position.Src == nil || !directive.Position.Src.BuiltIn {
+			directives++
+		}
+	}
+
+	add(statSectionElements, "fields", fields)
+	add(statSectionElements, "arguments", arguments)
+	add(statSectionElements, "input-fields", inputFields)
+	add(statSectionElements, "enum-values", enumValues)
+	add(statSectionElements, "directives", directives)
+
+	stats = append(stats,
+		StatInfo{Section: statSectionDocumentation, Name: "types", Value: percentage(describedTypes, len(defs)), Unit: "%"},
+		StatInfo{Section: statSectionDocumentation, Name: "fields", Value: percentage(describedFields, fields), Unit: "%"},
+	)
+
+	add(statSectionDeprecated, "fields", deprecatedFields)
+	add(statSectionDeprecated, "arguments", deprecatedArguments)
+	add(statSectionDeprecated, "input-fields", deprecatedInputFields)
+	add(statSectionDeprecated, "enum-values", deprecatedEnumValues)
+
+	// Root operations
+	roots := []struct {
+		name string
+		def  *ast.Definition
+	}{{"query", schema.Query}, {"mutation", schema.Mutation}, {"subscription", schema.Subscription}}
+	rootTypes := 0
+	for _, root := range roots {
+		if root.def != nil {
+			rootTypes++
+		}
+	}
+	add(statSectionOperations, "root-types", rootTypes)
+	for _, root := range roots {
+		if root.def != nil {
+			add(statSectionOperations, root.name+"-fields", len(userFields(root.def)))
+		}
+	}
+
+	if schema.Query != nil {
+		add(statSectionDepth, "max-from-query", maxDepthFrom(schema, schema.Query))
+	}
+
+	// Rankings
+	stats = append(stats, topStats(statSectionMostFields, fieldCounts, top)...)
+
+	referenceCounts := map[string]int{}
+	for _, def := range defs {
+		referenceCounts[def.Name] = len(findReferences(schema, def.Name, "", ""))
+	}
+	stats = append(stats, topStats(statSectionMostReferenced, referenceCounts, top)...)
+
+	return stats
+}
+
+func NewStatsCmd() *cobra.Command {
+	opts := &statsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Shows statistics about the size and health of the schema",
+		Long: `Shows statistics about the size and health of the schema.
+
+Each statistic has a section and a name:
+
+  types            number of types by kind, and the total
+  elements         number of fields, arguments, input fields, enum values
+                   and custom directives
+  documentation    percentage of types and fields with a description
+  deprecated       number of deprecated fields, arguments, input fields and
+                   enum values
+  operations       number of root operation types and their fields
+  depth            how many nested selections it takes to reach the most
+                   deeply nested type from Query (following shortest paths)
+  most-fields      the types with the most fields
+  most-referenced  the types referenced the most (as counted by gqlx references)
+
+Built-in scalars, introspection types and built-in directives are not counted.`,
+		Example: `  # Show a summary of the schema
+  gqlx stats
+
+  # Show the ten largest and most-referenced types
+  gqlx stats --top 10
+
+  # Track documentation coverage in a dashboard
+  gqlx stats -f json | jq '.[] | select(.section == "documentation")'`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.top, "top", 5, "Number of types to list in the most-fields and most-referenced rankings")
+
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, opts *statsOptions) error {
+	if opts.top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	renderer := render.Renderer[StatInfo]{
+		Data:         collectStats(schema, opts.top),
+		TextFormat:   formatStatText,
+		PrettyFormat: formatStatsPretty,
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
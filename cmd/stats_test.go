@@ -0,0 +1,177 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const statsTestSchema = `
+"A person with an account."
+type User implements Node {
+	id: ID!
+	"The display name."
+	name: String @deprecated(reason: "Use displayName")
+	displayName: String
+	posts(first: Int, after: String @deprecated): [Post!]!
+}
+
+type Post implements Node {
+	id: ID!
+	author: User!
+	comments: [Comment!]!
+}
+
+type Comment {
+	id: ID!
+	body: String
+}
+
+interface Node {
+	id: ID!
+}
+
+union SearchResult = User | Post
+
+enum Role {
+	ADMIN
+	MEMBER @deprecated
+}
+
+input UserFilter {
+	role: Role
+	legacy: String @deprecated
+}
+
+scalar DateTime
+
+directive @auth(role: Role) on FIELD_DEFINITION
+
+type Query {
+	me: User
+	node(id: ID!): Node
+	users(filter: UserFilter): [User!]!
+	search(term: String!): [SearchResult!]!
+}
+
+type Mutation {
+	noop: Boolean
+}
+`
+
+type statInfo struct {
+	Section string  `json:"section"`
+	Name    string  `json:"name"`
+	Value   float64 `json:"value"`
+	Unit    string  `json:"unit"`
+}
+
+func runStatsJSON(t *testing.T, args ...string) map[string]statInfo {
+	t.Helper()
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(statsTestSchema), 0644))
+
+	stdout, _, err := cmd.ExecuteWithArgs(append([]string{"stats", "-s", schemaPath, "-f", "json"}, args...))
+	require.NoError(t, err)
+
+	var stats []statInfo
+	require.NoError(t, json.Unmarshal([]byte(stdout), &stats))
+
+	bySection := map[string]statInfo{}
+	for _, stat := range stats {
+		bySection[stat.Section+"."+stat.Name] = stat
+	}
+	return bySection
+}
+
+func TestStats_CountsTypesByKind(t *testing.T) {
+	stats := runStatsJSON(t)
+
+	// Built-in scalars and introspection types are not counted
+	assert.Equal(t, float64(1), stats["types.scalar"].Value)
+	assert.Equal(t, float64(5), stats["types.type"].Value)
+	assert.Equal(t, float64(1), stats["types.interface"].Value)
+	assert.Equal(t, float64(1), stats["types.union"].Value)
+	assert.Equal(t, float64(1), stats["types.enum"].Value)
+	assert.Equal(t, float64(1), stats["types.input"].Value)
+	assert.Equal(t, float64(10), stats["types.total"].Value)
+}
+
+func TestStats_CountsElements(t *testing.T) {
+	stats := runStatsJSON(t)
+
+	// Introspection meta fields on Query are not counted
+	assert.Equal(t, float64(15), stats["elements.fields"].Value)
+	assert.Equal(t, float64(5), stats["elements.arguments"].Value)
+	assert.Equal(t, float64(2), stats["elements.input-fields"].Value)
+	assert.Equal(t, float64(2), stats["elements.enum-values"].Value)
+	assert.Equal(t, float64(1), stats["elements.directives"].Value)
+}
+
+func TestStats_Documentation(t *testing.T) {
+	stats := runStatsJSON(t)
+
+	assert.Equal(t, statInfo{Section: "documentation", Name: "types", Value: 10, Unit: "%"}, stats["documentation.types"])
+	assert.Equal(t, 6.7, stats["documentation.fields"].Value)
+}
+
+func TestStats_Deprecations(t *testing.T) {
+	stats := runStatsJSON(t)
+
+	assert.Equal(t, float64(1), stats["deprecated.fields"].Value)
+	assert.Equal(t, float64(1), stats["deprecated.arguments"].Value)
+	assert.Equal(t, float64(1), stats["deprecated.input-fields"].Value)
+	assert.Equal(t, float64(1), stats["deprecated.enum-values"].Value)
+}
+
+func TestStats_OperationsAndDepth(t *testing.T) {
+	stats := runStatsJSON(t)
+
+	assert.Equal(t, float64(2), stats["operations.root-types"].Value)
+	assert.Equal(t, float64(4), stats["operations.query-fields"].Value)
+	assert.Equal(t, float64(1), stats["operations.mutation-fields"].Value)
+	assert.NotContains(t, stats, "operations.subscription-fields")
+	// Query.node reaches Post, and Post.comments reaches Comment
+	assert.Equal(t, float64(2), stats["depth.max-from-query"].Value)
+}
+
+func TestStats_Rankings(t *testing.T) {
+	stats := runStatsJSON(t, "--top", "2")
+
+	assert.Equal(t, float64(4), stats["most-fields.Query"].Value)
+	assert.Equal(t, float64(4), stats["most-fields.User"].Value)
+	assert.NotContains(t, stats, "most-fields.Post")
+
+	assert.Equal(t, float64(4), stats["most-referenced.User"].Value)
+	assert.Equal(t, float64(3), stats["most-referenced.Node"].Value)
+	assert.NotContains(t, stats, "most-referenced.Post")
+}
+
+func TestStats_TextFormat(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(statsTestSchema), 0644))
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"stats", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+
+	assert.Contains(t, stdout, "types.interface: 1\n")
+	assert.Contains(t, stdout, "documentation.types: 10%\n")
+	assert.Contains(t, stdout, "most-fields.Query: 4\n")
+}
+
+func TestStats_NegativeTop(t *testing.T) {
+	dir := t.TempDir()
+	schemaPath := filepath.Join(dir, "schema.graphql")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(statsTestSchema), 0644))
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"stats", "-s", schemaPath, "--top", "-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--top must not be negative")
+}
@@ -0,0 +1,197 @@
+/*
+Copyright © 2026 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/tree"
+	"github.com/samwightt/gqlx/pkg/render"
+	"github.com/spf13/cobra"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type treeOptions struct {
+	depth          int
+	hideScalars    bool
+	hideDeprecated bool
+}
+
+func NewTreeCmd() *cobra.Command {
+	opts := &treeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tree <type>",
+		Short: "Shows the nested field structure of a type as a tree",
+		Long: `Shows the fields of a type and, nested under them, the fields of the types
+they return, down to --depth levels.
+
+Each field shows its arguments and type. Fields returning a type that is
+already expanded above them are marked (cycle) and not expanded again. Unions
+are expanded into an "... on Type" node for each member.
+
+Output formats:
+  text, pretty  An indented tree
+  json          Nested objects with the fields of each field under "fields"`,
+		Example: `  # Show User three levels deep
+  gqlx tree User --depth 3
+
+  # Only show the object structure
+  gqlx tree User --hide-scalars --hide-deprecated`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTree(cmd, args[0], opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.depth, "depth", 2, "Levels of fields to show")
+	cmd.Flags().BoolVar(&opts.hideScalars, "hide-scalars", false, "Hide scalar and enum fields")
+	cmd.Flags().BoolVar(&opts.hideDeprecated, "hide-deprecated", false, "Hide deprecated fields")
+
+	return cmd
+}
+
+// isListType reports whether a type is a list, possibly non-null.
+func isListType(t *ast.Type) bool {
+	return t.Elem != nil
+}
+
+// treeNodes returns the nodes for the fields of a type at the given level,
+// starting from 1. ancestors are the types expanded above them.
+func treeNodes(schema *ast.Schema, def *ast.Definition, level int, ancestors []string, opts *treeOptions) []TreeNode {
+	ancestors = append(ancestors, def.Name)
+
+	if def.Kind == ast.Union {
+		var nodes []TreeNode
+		for _, member := range concreteTypes(schema, def) {
+			node := TreeNode{Name: "... on " + member.Name, Type: member.Name}
+			if slices.Contains(ancestors, member.Name) {
+				node.Cycle = true
+			} else {
+				node.Fields = treeNodes(schema, member, level, ancestors, opts)
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	var nodes []TreeNode
+	for _, field := range def.Fields {
+		if strings.HasPrefix(field.Name, "__") {
+			continue
+		}
+		deprecated := isFieldDeprecated(field)
+		if deprecated && opts.hideDeprecated {
+			continue
+		}
+		target := schema.Types[field.Type.Name()]
+		leaf := target.Kind == ast.Scalar || target.Kind == ast.Enum
+		if leaf && opts.hideScalars {
+			continue
+		}
+
+		info := fieldToInfo(field)
+		node := TreeNode{
+			Name:       info.Name,
+			Type:       info.Type,
+			Arguments:  info.Arguments,
+			List:       isListType(field.Type),
+			Deprecated: deprecated,
+		}
+		switch {
+		case leaf:
+		case slices.Contains(ancestors, target.Name):
+			node.Cycle = true
+		case level < opts.depth:
+			node.Fields = treeNodes(schema, target, level+1, ancestors, opts)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func formatTreeNode(node TreeNode) string {
+	line := node.Name
+	if len(node.Arguments) > 0 {
+		var args []string
+		for _, arg := range node.Arguments {
+			args = append(args, arg.Name+": "+arg.Type)
+		}
+		line += "(" + strings.Join(args, ", ") + ")"
+	}
+	if node.Type != "" && !strings.HasPrefix(node.Name, "... on ") {
+		line += ": " + node.Type
+	}
+	if node.Deprecated {
+		line += " (deprecated)"
+	}
+	if node.Cycle {
+		line += " (cycle)"
+	}
+	return line
+}
+
+func formatTreeText(node TreeNode, indent string) string {
+	lines := []string{indent + formatTreeNode(node)}
+	for _, child := range node.Fields {
+		lines = append(lines, formatTreeText(child, indent+"  "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatTreePretty(node TreeNode) *tree.Tree {
+	t := tree.Root(formatTreeNode(node))
+	for _, child := range node.Fields {
+		if len(child.Fields) > 0 {
+			t.Child(formatTreePretty(child))
+		} else {
+			t.Child(formatTreeNode(child))
+		}
+	}
+	return t
+}
+
+func runTree(cmd *cobra.Command, typeName string, opts *treeOptions) error {
+	if opts.depth < 1 {
+		return fmt.Errorf("--depth must be at least 1")
+	}
+
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	if err := validateTypeExists(schema, typeName, "type"); err != nil {
+		return err
+	}
+	def := schema.Types[typeName]
+	if !slices.Contains([]ast.DefinitionKind{ast.Object, ast.Interface, ast.Union}, def.Kind) {
+		return fmt.Errorf("'%s' has no fields (it's a %s)", typeName, kindToString(string(def.Kind)))
+	}
+
+	nodes := treeNodes(schema, def, 1, nil, opts)
+	if len(nodes) == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "No fields found that match the filters.")
+	}
+
+	renderer := render.Renderer[TreeNode]{
+		Data: nodes,
+		TextFormat: func(node TreeNode) string {
+			return formatTreeText(node, "")
+		},
+		PrettyFormat: func(nodes []TreeNode) string {
+			return formatTreePretty(TreeNode{Name: typeName, Fields: nodes}).String()
+		},
+	}
+
+	output, err := renderer.Render(outputFormat)
+	if err != nil {
+		return fmt.Errorf("error rendering output: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), output)
+	return nil
+}
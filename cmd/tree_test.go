@@ -0,0 +1,138 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/samwightt/gqlx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const treeTestSchema = `
+type Query {
+	user(id: ID!): User
+}
+
+type User {
+	id: ID!
+	name: String @deprecated(reason: "Use profile")
+	profile: Profile!
+	friends(first: Int!): [User!]!
+	pinned: Pinned
+}
+
+type Profile {
+	bio: String
+	avatar(size: Int = 64): Image
+}
+
+type Image {
+	url: String!
+}
+
+union Pinned = Image | Profile
+`
+
+func TestTree_Text(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"tree", "User", "--depth", "2", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, `id: ID!
+name: String (deprecated)
+profile: Profile!
+  bio: String
+  avatar(size: Int): Image
+friends(first: Int!): [User!]! (cycle)
+pinned: Pinned
+  ... on Image
+    url: String!
+  ... on Profile
+    bio: String
+    avatar(size: Int): Image
+`, stdout)
+}
+
+func TestTree_Depth(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"tree", "User", "--depth", "3", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "  avatar(size: Int): Image\n    url: String!\n")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"tree", "User", "--depth", "0", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--depth must be at least 1")
+}
+
+func TestTree_Filters(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"tree", "User", "--hide-scalars", "--hide-deprecated", "-s", schemaPath, "-f", "text"})
+	require.NoError(t, err)
+	assert.Equal(t, `profile: Profile!
+  avatar(size: Int): Image
+friends(first: Int!): [User!]! (cycle)
+pinned: Pinned
+  ... on Image
+  ... on Profile
+    avatar(size: Int): Image
+`, stdout)
+}
+
+func TestTree_JSON(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"tree", "User", "--hide-scalars", "-s", schemaPath, "-f", "json"})
+	require.NoError(t, err)
+
+	type node struct {
+		Name      string `json:"name"`
+		Type      string `json:"type"`
+		Arguments []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"arguments"`
+		List   bool   `json:"list"`
+		Cycle  bool   `json:"cycle"`
+		Fields []node `json:"fields"`
+	}
+	var nodes []node
+	require.NoError(t, json.Unmarshal([]byte(stdout), &nodes))
+	require.Len(t, nodes, 3)
+
+	assert.Equal(t, "profile", nodes[0].Name)
+	require.Len(t, nodes[0].Fields, 1)
+	assert.Equal(t, "avatar", nodes[0].Fields[0].Name)
+
+	friends := nodes[1]
+	assert.Equal(t, "[User!]!", friends.Type)
+	assert.True(t, friends.List)
+	assert.True(t, friends.Cycle)
+	assert.Empty(t, friends.Fields)
+	require.Len(t, friends.Arguments, 1)
+	assert.Equal(t, "first", friends.Arguments[0].Name)
+}
+
+func TestTree_Pretty(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	stdout, _, err := cmd.ExecuteWithArgs([]string{"tree", "Profile", "-s", schemaPath, "-f", "pretty"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Profile\n")
+	assert.Contains(t, stdout, "└── avatar(size: Int): Image")
+	assert.Contains(t, stdout, "    └── url: String!")
+}
+
+func TestTree_Errors(t *testing.T) {
+	schemaPath := writeTestSchema(t, treeTestSchema)
+
+	_, _, err := cmd.ExecuteWithArgs([]string{"tree", "Usr", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "type 'Usr' does not exist in schema, did you mean 'User'?")
+
+	_, _, err = cmd.ExecuteWithArgs([]string{"tree", "String", "-s", schemaPath})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'String' has no fields (it's a scalar)")
+}
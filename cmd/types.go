@@ -57,9 +57,9 @@ func formatTypeText(t TypeInfo) string {
 	kind := kindToString(t.Kind)
 	if t.Description != "" {
 		desc := strings.ReplaceAll(t.Description, "\n", " ")
-		return fmt.Sprintf("%s %s # %s", kind, t.Name, desc)
+		return fmt.Sprintf("%s%s %s # %s", locationPrefix(t.Location), kind, t.Name, desc)
 	}
-	return fmt.Sprintf("%s %s", kind, t.Name)
+	return fmt.Sprintf("%s%s %s", locationPrefix(t.Location), kind, t.Name)
 }
 
 func formatTypesPretty(types []TypeInfo) string {
@@ -67,9 +67,9 @@ func formatTypesPretty(types []TypeInfo) string {
 
 	for _, t := range types {
 		desc := strings.ReplaceAll(t.Description, "\n", " ")
-		tbl.Row(kindToString(t.Kind), t.Name, desc)
+		tbl.Row(kindToString(t.Kind), t.Name, desc, formatLocation(t.Location))
 	}
-	tbl.Headers("kind", "name", "description")
+	tbl.Headers("kind", "name", "description", "location")
 
 	return tbl.String()
 }
@@ -292,6 +292,14 @@ Multiple filters can be combined and are applied with AND logic.`,
 		},
 	}
 
+	addTypesFilterFlags(cmd, opts)
+
+	return cmd
+}
+
+// addTypesFilterFlags registers the type filter flags shared by commands that
+// select a subset of the schema's types.
+func addTypesFilterFlags(cmd *cobra.Command, opts *typesOptions) {
 	cmd.Flags().StringVar(&opts.implements, "implements", "", "Filter to types that implement the given interface")
 	cmd.Flags().StringArrayVar(&opts.hasField, "has-field", nil, "Filter to types that have the given field (can be specified multiple times)")
 	cmd.Flags().StringArrayVar(&opts.kind, "kind", nil, "Filter to types of the given kind: scalar, type, interface, union, enum, input (if specified multiple times, applied using OR logic)")
@@ -308,48 +316,43 @@ Multiple filters can be combined and are applied with AND logic.`,
 	cmd.Flags().BoolVar(&opts.union, "union", false, "Filter to union types")
 	cmd.Flags().BoolVar(&opts.enum, "enum", false, "Filter to enum types")
 	cmd.Flags().BoolVar(&opts.input, "input", false, "Filter to input types")
-
-	return cmd
 }
 
-func runTypes(cmd *cobra.Command, args []string, opts *typesOptions) error {
+// filterTypes returns the schema's types that match every filter in opts.
+// Types are returned in map iteration order; callers sort as needed.
+func filterTypes(schema *ast.Schema, opts *typesOptions) ([]*ast.Definition, error) {
 	var typesNameRegex *regexp.Regexp
 	if opts.nameRegex != "" {
 		var err error
 		typesNameRegex, err = regexp.Compile(opts.nameRegex)
 		if err != nil {
-			return fmt.Errorf("invalid regex pattern for --name-regex: %w", err)
+			return nil, fmt.Errorf("invalid regex pattern for --name-regex: %w", err)
 		}
 	}
 
-	schema, err := loadCliForSchema()
-	if err != nil {
-		return err
-	}
-
 	if err := validateImplementsFilter(schema, opts.implements); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Collect type sets for all used-by filters
 	usedBySets, err := collectUsedBySets(schema, opts.usedBy)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	usedByAnySets, err := collectUsedBySets(schema, opts.usedByAny)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	notUsedBySets, err := collectUsedBySets(schema, opts.notUsedBy)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	notUsedByAllSets, err := collectUsedBySets(schema, opts.notUsedByAll)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var types []TypeInfo
+	var types []*ast.Definition
 	for _, graphqlType := range schema.Types {
 		if !matchesImplementsFilter(graphqlType, opts.implements) {
 			continue
@@ -394,10 +397,30 @@ func runTypes(cmd *cobra.Command, args []string, opts *typesOptions) error {
 			continue
 		}
 
+		types = append(types, graphqlType)
+	}
+
+	return types, nil
+}
+
+func runTypes(cmd *cobra.Command, args []string, opts *typesOptions) error {
+	schema, err := loadCliForSchema()
+	if err != nil {
+		return err
+	}
+
+	defs, err := filterTypes(schema, opts)
+	if err != nil {
+		return err
+	}
+
+	var types []TypeInfo
+	for _, graphqlType := range defs {
 		types = append(types, TypeInfo{
 			Name:        graphqlType.Name,
 			Kind:        string(graphqlType.Kind),
 			Description: graphqlType.Description,
+			Location:    sourceLocation(graphqlType.Position),
 		})
 	}
 
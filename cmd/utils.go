@@ -3,17 +3,17 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"iter"
 	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
 
 	"github.com/agnivade/levenshtein"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
-	gqlparser "github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
-	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 var tableStyle = lipgloss.NewStyle().PaddingRight(1)
@@ -116,42 +116,88 @@ func filterSlice[T any](items []T, predicate func(T) bool) []T {
 	return result
 }
 
-func loadSchema() (*ast.Schema, error) {
-	path, err := filepath.Abs(schemaFilePath)
-	if err != nil {
-		return nil, err
+// sourceLocation returns where a schema element is defined, or nil for
+// built-in elements.
+func sourceLocation(pos *ast.Position) *SourceLocation {
+	if pos == nil || pos.Src == nil || pos.Src.BuiltIn {
+		return nil
 	}
+	return &SourceLocation{File: pos.Src.Name, Line: pos.Line, Column: pos.Column}
+}
 
-	bytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
+// sourceFilePath returns the absolute path of the file, among paths, that a
+// loaded schema source is named after.
+func sourceFilePath(name string, paths []string) string {
+	for _, path := range paths {
+		if sourceName(path) == name {
+			name = path
+			break
+		}
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
 	}
-	strVal := string(bytes)
+	return name
+}
 
-	fileName := filepath.Base(path)
-	source := ast.Source{
-		Input: strVal,
-		Name:  fileName,
+// formatLocation formats a location as file:line. When stdout is a terminal,
+// it's an OSC 8 hyperlink to the file.
+func formatLocation(loc *SourceLocation) string {
+	if loc == nil {
+		return ""
 	}
-	schema, err := gqlparser.LoadSchema(&source)
+	text := fmt.Sprintf("%s:%d", loc.File, loc.Line)
+	if !hyperlinks {
+		return text
+	}
+	host, _ := os.Hostname()
+	link := &url.URL{Scheme: "file", Host: host, Path: filepath.ToSlash(sourceFilePath(loc.File, append(schemaPaths(), subgraphPaths...)))}
+	return "\x1b]8;;" + link.String() + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// locationPrefix returns "file:line: " for text output, like compiler and grep
+// output, or "-: " for built-in elements so every line has the same columns.
+func locationPrefix(loc *SourceLocation) string {
+	if loc == nil {
+		return "-: "
+	}
+	return formatLocation(loc) + ": "
+}
+
+func loadSchema() (*ast.Schema, error) {
+	if len(subgraphPaths) > 0 {
+		return composeSubgraphs(subgraphPaths)
+	}
+
+	sources, err := readSchemaSources(schemaPaths())
 	if err != nil {
 		return nil, err
 	}
-
+	schema, errs := checkSchema(sources, federation)
+	if len(errs) > 0 {
+		return nil, &schemaErrors{errors: errs, sources: sources}
+	}
 	return schema, nil
 }
 
 func loadCliForSchema() (*ast.Schema, error) {
 	schema, err := loadSchema()
 
+	if err != nil && len(subgraphPaths) > 0 {
+		// Subgraph errors already name the file they come from
+		return nil, err
+	}
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) && errors.Is(err, os.ErrNotExist) {
+			if len(configSchemaPaths) > 0 {
+				return nil, fmt.Errorf("schema file does not exist: %s (from %s)", displayPath(pathErr.Path), displayPath(activeProject.configPath))
+			}
 			return nil, fmt.Errorf("schema file does not exist: %s", schemaFilePath)
 		}
-		var parsingError *gqlerror.Error
-
-		if errors.As(err, &parsingError) {
-			return nil, fmt.Errorf("GraphQL schema parsing error: %v", parsingError)
+		var schemaErrs *schemaErrors
+		if errors.As(err, &schemaErrs) {
+			return nil, err
 		}
 
 		return nil, fmt.Errorf("unexpected error: %v", err)
@@ -159,3 +205,16 @@ func loadCliForSchema() (*ast.Schema, error) {
 
 	return schema, nil
 }
+
+// deprecationReason reports whether the directives include @deprecated and, if
+// so, its reason. A missing reason defaults to the spec's "No longer supported".
+func deprecationReason(directives ast.DirectiveList) (string, bool) {
+	deprecated := directives.ForName("deprecated")
+	if deprecated == nil {
+		return "", false
+	}
+	if arg := deprecated.Arguments.ForName("reason"); arg != nil && arg.Value != nil && arg.Value.Kind != ast.NullValue {
+		return arg.Value.Raw, true
+	}
+	return "No longer supported", true
+}
@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/samwightt/gqlx/pkg/diagnostic"
@@ -18,6 +20,7 @@ import (
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	"github.com/vektah/gqlparser/v2/validator"
+	"golang.org/x/term"
 )
 
 // ErrValidationFailed is returned when a query fails validation.
@@ -43,22 +46,30 @@ func convertGQLErrors(errs gqlerror.List) []ValidationError {
 	return result
 }
 
-func validateQuery(querySource string, queryContent string, schema *ast.Schema) *ValidationResult {
+// loadValidQuery parses and validates a query document against the schema.
+// The returned document is only non-nil when the query is valid, in which case
+// its fields, fragments and spreads are linked to their schema definitions.
+func loadValidQuery(querySource string, queryContent string, schema *ast.Schema) (*ast.QueryDocument, *ValidationResult) {
 	// Parse query document
 	source := &ast.Source{Input: queryContent, Name: querySource}
 	doc, parseErr := gqlparser.LoadQuery(schema, source.Input)
 	if parseErr != nil {
 		// Parse errors are also validation failures
-		return &ValidationResult{Valid: false, Errors: convertGQLErrors(parseErr)}
+		return nil, &ValidationResult{Valid: false, Errors: convertGQLErrors(parseErr)}
 	}
 
 	// Validate against schema
 	errs := validator.Validate(schema, doc)
 	if len(errs) > 0 {
-		return &ValidationResult{Valid: false, Errors: convertGQLErrors(errs)}
+		return nil, &ValidationResult{Valid: false, Errors: convertGQLErrors(errs)}
 	}
 
-	return &ValidationResult{Valid: true}
+	return doc, &ValidationResult{Valid: true}
+}
+
+func validateQuery(querySource string, queryContent string, schema *ast.Schema) *ValidationResult {
+	_, result := loadValidQuery(querySource, queryContent, schema)
+	return result
 }
 
 // Validation Error Display
@@ -207,12 +218,17 @@ func formatValidationResultJSON(result *ValidationResult) (string, error) {
 }
 
 func NewValidateCmd() *cobra.Command {
+	var documents bool
+
 	cmd := &cobra.Command{
 		Use:   "validate [file]",
 		Short: "Type-check a GraphQL query against the schema",
 		Long: `Validates a GraphQL query, mutation, or subscription against the schema.
 
-The query can be provided as a file path argument or piped via stdin.
+The query can be provided as a file path argument or piped via stdin (or
+"-"). With --documents, or without a file when stdin is a terminal, every
+document matching the globs of the GraphQL config file's documents is
+validated instead.
 
 Exit codes:
   0 - Query is valid
@@ -220,7 +236,9 @@ Exit codes:
 
 Output formats:
   text    Human-readable error messages with locations
-  json    {"valid": bool, "errors": [...]}`,
+  json    {"valid": bool, "errors": [...]}
+          [{"file": "src/user.graphql", "valid": bool, "errors": [...]}, ...]
+          when validating the config documents`,
 		Example: `  # Validate from a file
   gqlx validate query.graphql
 
@@ -228,26 +246,56 @@ Output formats:
   echo "query { user { id } }" | gqlx validate
 
   # JSON output for CI integration
-  gqlx validate query.graphql -f json`,
-		Args:          cobra.MaximumNArgs(1),
-		SilenceUsage:  true,
-		SilenceErrors: true,
-		RunE:          runValidateCmd,
+  gqlx validate query.graphql -f json
+
+  # Validate the documents of a project in graphql.config.yml
+  gqlx validate --project web
+
+  # Validate the config documents in CI, where stdin is not a terminal
+  gqlx validate --documents -f json`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidateCmd(cmd, args, documents)
+		},
 	}
 
+	cmd.Flags().BoolVar(&documents, "documents", false, "Validate the documents of the GraphQL config file instead of a file or stdin")
+
 	return cmd
 }
 
-func runValidateCmd(cmd *cobra.Command, args []string) error {
+// stdinIsTerminal reports whether nothing can be piped to the command's stdin.
+func stdinIsTerminal(cmd *cobra.Command) bool {
+	in, ok := cmd.InOrStdin().(*os.File)
+	return ok && term.IsTerminal(int(in.Fd()))
+}
+
+func runValidateCmd(cmd *cobra.Command, args []string, documents bool) error {
+	hasDocuments := activeProject != nil && len(activeProject.config.Documents) > 0
+	if documents {
+		if len(args) > 0 {
+			return fmt.Errorf("--documents can't be used with a file")
+		}
+		if !hasDocuments {
+			return fmt.Errorf("--documents needs a GraphQL config file that defines documents")
+		}
+	}
+
 	schema, err := loadCliForSchema()
 	if err != nil {
 		return err
 	}
 
+	// A query piped on stdin is validated even when the config has documents
+	if documents || (len(args) == 0 && hasDocuments && stdinIsTerminal(cmd)) {
+		return runValidateDocuments(cmd, schema)
+	}
+
 	var queryContent string
 	var querySource string
 
-	if len(args) == 1 {
+	if len(args) == 1 && args[0] != "-" {
 		// Read from file
 		querySource = args[0]
 		bytes, err := os.ReadFile(querySource)
@@ -281,8 +329,67 @@ func runValidateCmd(cmd *cobra.Command, args []string) error {
 
 	// Return error if validation failed (causes exit code 1)
 	if !result.Valid {
+		// The errors are the error message
+		cmd.SilenceErrors = true
 		return ErrValidationFailed
 	}
 
 	return nil
 }
+
+// runValidateDocuments validates every document the config project's
+// documents globs match. Only documents with errors are printed in text.
+func runValidateDocuments(cmd *cobra.Command, schema *ast.Schema) error {
+	files, err := activeProject.files(activeProject.config.Documents)
+	if err != nil {
+		return err
+	}
+
+	var schemaFiles []string
+	for _, schemaPath := range schemaPaths() {
+		abs, _ := filepath.Abs(schemaPath)
+		schemaFiles = append(schemaFiles, abs)
+	}
+
+	var results []DocumentValidationResult
+	var invalid int
+	var output string
+	for _, file := range files {
+		if abs, _ := filepath.Abs(file); slices.Contains(schemaFiles, abs) {
+			continue
+		}
+		bytes, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read query file: %w", err)
+		}
+
+		result := validateQuery(file, string(bytes), schema)
+		results = append(results, DocumentValidationResult{File: file, ValidationResult: *result})
+		if !result.Valid {
+			invalid++
+			output += formatValidationResultText(result, file, string(bytes), schema) + "\n"
+		}
+	}
+
+	switch outputFormat {
+	case "json":
+		bytes, err := json.MarshalIndent(append([]DocumentValidationResult{}, results...), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(bytes))
+	default:
+		if invalid == 0 {
+			output += fmt.Sprintf("✓ %d documents are valid\n", len(results))
+		} else {
+			output += fmt.Sprintf("✗ %d of %d documents have errors\n", invalid, len(results))
+		}
+		fmt.Fprint(cmd.OutOrStdout(), output)
+	}
+
+	if invalid > 0 {
+		cmd.SilenceErrors = true
+		return ErrValidationFailed
+	}
+	return nil
+}
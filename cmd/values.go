@@ -33,9 +33,9 @@ func formatValueText(v ValueInfo) string {
 	name := formatValueName(v)
 	if v.Description != "" {
 		desc := strings.ReplaceAll(v.Description, "\n", " ")
-		return fmt.Sprintf("%s # %s", name, desc)
+		return fmt.Sprintf("%s%s # %s", locationPrefix(v.Location), name, desc)
 	}
-	return name
+	return locationPrefix(v.Location) + name
 }
 
 func formatValuesPretty(values []ValueInfo) string {
@@ -44,9 +44,9 @@ func formatValuesPretty(values []ValueInfo) string {
 	for _, v := range values {
 		name := formatValueName(v)
 		desc := strings.ReplaceAll(v.Description, "\n", " ")
-		t.Row(name, desc)
+		t.Row(name, desc, formatLocation(v.Location))
 	}
-	t.Headers("value", "description")
+	t.Headers("value", "description", "location")
 
 	return t.String()
 }
@@ -117,6 +117,7 @@ func runValues(cmd *cobra.Command, args []string, opts *valuesOptions) error {
 					EnumName:    graphqlType.Name,
 					Name:        value.Name,
 					Description: value.Description,
+					Location:    sourceLocation(value.Position),
 				})
 			}
 		}
@@ -147,6 +148,7 @@ func runValues(cmd *cobra.Command, args []string, opts *valuesOptions) error {
 			values = append(values, ValueInfo{
 				Name:        value.Name,
 				Description: value.Description,
+				Location:    sourceLocation(value.Position),
 			})
 		}
 	}